@@ -14,3 +14,9 @@ type Agent interface {
 	GetInputKeys() []string
 	GetOutputKeys() []string
 }
+
+// StoppedResponder is implemented by agents that can turn the intermediate steps
+// into a best-effort final answer when the executor stops them early.
+type StoppedResponder interface {
+	StoppedResponse(ctx context.Context, intermediateSteps []schema.AgentStep, inputs map[string]string) (*schema.AgentFinish, error) //nolint:lll
+}
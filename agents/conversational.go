@@ -2,7 +2,6 @@ package agents
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"strings"
 
@@ -33,7 +32,10 @@ type ConversationalAgent struct {
 	OutputKey string
 }
 
-var _ Agent = (*ConversationalAgent)(nil)
+var (
+	_ Agent            = (*ConversationalAgent)(nil)
+	_ StoppedResponder = (*ConversationalAgent)(nil)
+)
 
 func NewConversationalAgent(llm llms.LanguageModel, tools []tools.Tool, opts ...CreationOption) *ConversationalAgent {
 	options := conversationalDefaultOptions()
@@ -65,7 +67,7 @@ func (a *ConversationalAgent) Plan(
 		ctx,
 		a.Chain,
 		fullInputs,
-		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
+		chainCallOptions(ctx, chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}))...,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -74,6 +76,28 @@ func (a *ConversationalAgent) Plan(
 	return a.parseOutput(output)
 }
 
+// StoppedResponse makes the agent give a final answer based on the intermediate
+// steps. It is used by the executor when the agent is stopped early.
+func (a *ConversationalAgent) StoppedResponse(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	fullInputs["agent_scratchpad"] = stoppedScratchPad(intermediateSteps)
+
+	output, err := chains.Predict(ctx, a.Chain, fullInputs, chainCallOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return finishFromOutput(a.OutputKey, output, a.parseOutput), nil
+}
+
 func (a *ConversationalAgent) GetInputKeys() []string {
 	chainInputs := a.Chain.GetInputKeys()
 
@@ -121,7 +145,7 @@ func (a *ConversationalAgent) parseOutput(output string) ([]schema.AgentAction,
 	r := regexp.MustCompile(`Action: (.*?)[\n]*Action Input: (.*)`)
 	matches := r.FindStringSubmatch(output)
 	if len(matches) == 0 {
-		return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+		return nil, nil, &OutputParseError{Output: output}
 	}
 
 	return []schema.AgentAction{
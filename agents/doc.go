@@ -12,6 +12,10 @@
 // descriptions of tools) to decide what action to take. This agent is
 // optimized to be used with LLMs.
 //
+// The OpenAIFunctionsAgent uses the function calling feature of chat models
+// instead. Every tool is described as a function, and the function calls of
+// the model are turned into actions without parsing free text.
+//
 // To make agents more powerful we need to make them iterative, ie. call the
 // model multiple times until they arrive at the final answer. That's the job of
 // the Executor. The Executor is an Agent and set of Tools. The agent executor is
@@ -19,4 +23,17 @@
 // calling the tool that the action references with the corresponding input,
 // getting the output of the tool, and then passing all that information back
 // into the Agent to get the next action it should take.
+//
+// By default the Executor stops when a tool fails or when the output of the
+// agent can not be parsed. WithToolErrorPolicy and WithParserErrorPolicy with
+// ErrorAsObservation turn these errors into observations instead, so the model
+// gets a chance to correct itself.
+//
+// A run can be limited with WithMaxIterations, WithMaxExecutionTime and
+// WithMaxTokens. WithEarlyStoppingMethod decides whether a stopped run returns
+// an error, a canned answer, or a final answer generated from the steps so far.
+//
+// Executor.Stream and Executor.StreamIterator run the executor in the
+// background and yield an Event for every planned action, tool start and end,
+// streamed llm chunk, and the final answer.
 package agents
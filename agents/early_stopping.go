@@ -0,0 +1,183 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const (
+	// _forcedStopOutput is the final answer used by EarlyStoppingForce.
+	_forcedStopOutput = "Agent stopped due to iteration limit, time limit or token budget."
+	// _stoppedResponseInstruction is added to the scratchpad to make the agent
+	// give a final answer with EarlyStoppingGenerate.
+	_stoppedResponseInstruction = "\n\nI now need to return a final answer based on the previous steps:"
+	// _tokenCountModel is the model whose tokenizer is used for the token budget
+	// when the llms do not report their usage.
+	_tokenCountModel = "gpt-3.5-turbo"
+)
+
+// EarlyStoppingMethod decides what the executor returns when the agent is
+// stopped before giving a final answer.
+type EarlyStoppingMethod string
+
+const (
+	// EarlyStoppingNone returns an error when the agent is stopped. It is the
+	// default.
+	EarlyStoppingNone EarlyStoppingMethod = ""
+	// EarlyStoppingForce returns a canned final answer saying that the agent was
+	// stopped.
+	EarlyStoppingForce EarlyStoppingMethod = "force"
+	// EarlyStoppingGenerate makes one last call to the agent to turn the
+	// intermediate steps into a best-effort final answer. Agents that do not
+	// implement StoppedResponder fall back to EarlyStoppingForce.
+	EarlyStoppingGenerate EarlyStoppingMethod = "generate"
+)
+
+// runContext returns the context of a run, which is canceled when the run
+// exceeds the max execution time of the executor.
+func (e Executor) runContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.MaxExecutionTime > 0 {
+		return context.WithTimeout(ctx, e.MaxExecutionTime)
+	}
+	return context.WithCancel(ctx)
+}
+
+// timedOut reports whether the run context was canceled by the max execution
+// time rather than by the context of the caller.
+func timedOut(ctx, runCtx context.Context) bool {
+	return runCtx.Err() != nil && ctx.Err() == nil
+}
+
+// checkBudget returns an error if the run has used up its time or token budget.
+func (e Executor) checkBudget(ctx, runCtx context.Context, usage *usageCounter, steps []schema.AgentStep) error {
+	if timedOut(ctx, runCtx) {
+		return ErrExecutionTimeExceeded
+	}
+
+	if e.MaxTokens > 0 {
+		tokens, ok := usage.total()
+		if !ok {
+			tokens = countStepTokens(steps)
+		}
+		if tokens > e.MaxTokens {
+			return ErrTokenBudgetExceeded
+		}
+	}
+
+	return nil
+}
+
+// usageCounter is a callbacks handler that sums up the token usage the llms of
+// a run report.
+type usageCounter struct {
+	callbacks.SimpleHandler
+
+	mu       sync.Mutex
+	tokens   int
+	reported bool
+}
+
+func (c *usageCounter) HandleLLMEnd(_ context.Context, result llms.LLMResult) {
+	if result.Usage == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens += result.Usage.TotalTokens
+	c.reported = true
+}
+
+// total returns the tokens reported so far, and false if no llm reported its
+// usage.
+func (c *usageCounter) total() (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens, c.reported
+}
+
+// countStepTokens counts the tokens of the outputs of the agent and the
+// observations of the steps. It is used for the token budget of runs whose
+// llms do not report their usage.
+func countStepTokens(steps []schema.AgentStep) int {
+	tokens := 0
+	for _, step := range steps {
+		tokens += llms.CountTokens(_tokenCountModel, step.Action.Log)
+		tokens += llms.CountTokens(_tokenCountModel, step.Observation)
+	}
+
+	return tokens
+}
+
+// stop applies the early stopping method of the executor after the agent was
+// stopped for the reason given.
+func (e Executor) stop(
+	ctx context.Context,
+	reason error,
+	steps []schema.AgentStep,
+	inputs map[string]string,
+) (map[string]any, error) {
+	switch e.EarlyStoppingMethod {
+	case EarlyStoppingNone:
+		return nil, reason
+	case EarlyStoppingForce:
+		e.handleText(ctx, fmt.Sprintf("%s, returning a forced final answer", reason.Error()))
+		return e.getReturn(e.forcedFinish(), steps), nil
+	case EarlyStoppingGenerate:
+		responder, ok := e.Agent.(StoppedResponder)
+		if !ok {
+			e.handleText(ctx, fmt.Sprintf("%s, agent can not generate a final answer, returning a forced final answer",
+				reason.Error()))
+			return e.getReturn(e.forcedFinish(), steps), nil
+		}
+
+		e.handleText(ctx, fmt.Sprintf("%s, generating a final answer", reason.Error()))
+		finish, err := responder.StoppedResponse(ctx, steps, inputs)
+		if err != nil {
+			return nil, err
+		}
+		return e.getReturn(finish, steps), nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown early stopping method %q", ErrInvalidOptions, e.EarlyStoppingMethod)
+}
+
+func (e Executor) forcedFinish() *schema.AgentFinish {
+	returnValues := make(map[string]any)
+	for _, key := range e.Agent.GetOutputKeys() {
+		returnValues[key] = _forcedStopOutput
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: returnValues,
+		Log:          _forcedStopOutput,
+	}
+}
+
+// stoppedScratchPad is the scratchpad of the steps followed by the instruction
+// to give a final answer.
+func stoppedScratchPad(steps []schema.AgentStep) string {
+	return strings.TrimSuffix(constructScratchPad(steps), "\nThought:") + _stoppedResponseInstruction
+}
+
+// finishFromOutput makes a finish of output if parsing it as a final answer fails.
+func finishFromOutput(
+	outputKey string,
+	output string,
+	parse func(string) ([]schema.AgentAction, *schema.AgentFinish, error),
+) *schema.AgentFinish {
+	if _, finish, err := parse(output); err == nil && finish != nil {
+		return finish
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{outputKey: output},
+		Log:          output,
+	}
+}
@@ -1,6 +1,9 @@
 package agents
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -10,10 +13,19 @@ var (
 	// ErrNotFinished is returned if the agent does not give a finish before  the number of iterations
 	// is larger then max iterations.
 	ErrNotFinished = errors.New("agent not finished before max iterations")
+	// ErrExecutionTimeExceeded is returned if the agent does not give a finish before the max
+	// execution time of the executor.
+	ErrExecutionTimeExceeded = errors.New("agent not finished before max execution time")
+	// ErrTokenBudgetExceeded is returned if the agent does not give a finish before the token
+	// budget of the executor is used up.
+	ErrTokenBudgetExceeded = errors.New("agent not finished before token budget was used")
 	// ErrUnknownAgentType is returned if the type given to the initializer is invalid.
 	ErrUnknownAgentType = errors.New("unknown agent type")
 	// ErrInvalidOptions is returned if the options given to the initializer is invalid.
 	ErrInvalidOptions = errors.New("invalid options")
+	// ErrUnsupportedLLM is returned if the llm given to the initializer can not be
+	// used with the agent type.
+	ErrUnsupportedLLM = errors.New("llm not supported by agent type")
 
 	// ErrUnableToParseOutput is returned if the output of the llm is unparsable.
 	ErrUnableToParseOutput = errors.New("unable to parse agent output")
@@ -21,3 +33,18 @@ var (
 	// "text" filed that is not a string.
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
 )
+
+// OutputParseError is returned, wrapping ErrUnableToParseOutput, when the
+// output of the llm can not be parsed. It keeps the raw output, which the
+// executor gives back to the agent when parser errors are observations.
+type OutputParseError struct {
+	Output string
+}
+
+func (e *OutputParseError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnableToParseOutput.Error(), e.Output)
+}
+
+func (e *OutputParseError) Unwrap() error {
+	return ErrUnableToParseOutput
+}
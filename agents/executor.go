@@ -2,8 +2,11 @@ package agents
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/chains"
@@ -11,7 +14,29 @@ import (
 	"github.com/aresa7796/langchaingo/tools"
 )
 
-const _intermediateStepsOutputKey = "intermediateSteps"
+const (
+	_intermediateStepsOutputKey = "intermediateSteps"
+
+	// _parserErrorTool is the tool of the step added when the output of the agent
+	// could not be parsed.
+	_parserErrorTool = "_Exception"
+	// _parserErrorObservation is the observation of the step added when the output
+	// of the agent could not be parsed.
+	_parserErrorObservation = "Invalid format: the output could not be parsed. " +
+		"Follow the format instructions and try again."
+)
+
+// ErrorPolicy decides what the executor does when a tool fails or when the
+// output of the agent can not be parsed.
+type ErrorPolicy int
+
+const (
+	// FailFast stops the run and returns the error.
+	FailFast ErrorPolicy = iota
+	// ErrorAsObservation adds a step with the error as the observation and lets
+	// the agent continue, so the model can correct itself.
+	ErrorAsObservation
+)
 
 // Executor is the chain responsible for running agents.
 type Executor struct {
@@ -22,6 +47,30 @@ type Executor struct {
 
 	MaxIterations           int
 	ReturnIntermediateSteps bool
+	// EarlyStoppingMethod decides what is returned when the agent is stopped by
+	// MaxIterations, MaxExecutionTime or MaxTokens.
+	EarlyStoppingMethod EarlyStoppingMethod
+	// MaxExecutionTime is the max wall-clock time of a run. The context of the
+	// llm and tool calls of the run is canceled when it is exceeded. Zero means
+	// no limit.
+	MaxExecutionTime time.Duration
+	// MaxTokens is the max number of tokens of a run. It is checked before each
+	// iteration against the usage the llms of the run report, or, if they
+	// report none, against the tokens of the outputs of the agent and the
+	// observations. Zero means no limit.
+	MaxTokens int
+	// MaxConcurrency is the max number of actions of one step run at the same
+	// time. Values below two run the actions one after another.
+	MaxConcurrency int
+
+	// ToolErrorPolicy is the policy for errors returned by tools.
+	ToolErrorPolicy ErrorPolicy
+	// ParserErrorPolicy is the policy for errors wrapping ErrUnableToParseOutput
+	// returned by the agent.
+	ParserErrorPolicy ErrorPolicy
+
+	// emit sends the events of runs started with Stream.
+	emit func(context.Context, Event)
 }
 
 var (
@@ -43,6 +92,12 @@ func NewExecutor(agent Agent, tools []tools.Tool, opts ...CreationOption) Execut
 		MaxIterations:           options.maxIterations,
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
+		EarlyStoppingMethod:     options.earlyStoppingMethod,
+		MaxExecutionTime:        options.maxExecutionTime,
+		MaxTokens:               options.maxTokens,
+		MaxConcurrency:          options.maxConcurrency,
+		ToolErrorPolicy:         options.toolErrorPolicy,
+		ParserErrorPolicy:       options.parserErrorPolicy,
 	}
 }
 
@@ -53,9 +108,30 @@ func (e Executor) Call(ctx context.Context, inputValues map[string]any, _ ...cha
 	}
 	nameToTool := getNameToTool(e.Tools)
 
+	runCtx, cancel := e.runContext(ctx)
+	defer cancel()
+	usage := &usageCounter{}
+	if e.MaxTokens > 0 {
+		runCtx = callbacks.WithHandlers(runCtx, usage)
+	}
+
 	steps := make([]schema.AgentStep, 0)
 	for i := 0; i < e.MaxIterations; i++ {
-		actions, finish, err := e.Agent.Plan(ctx, steps, inputs)
+		if err := e.checkBudget(ctx, runCtx, usage, steps); err != nil {
+			return e.stop(ctx, err, steps, inputs)
+		}
+
+		actions, finish, err := e.Agent.Plan(runCtx, steps, inputs)
+		if err != nil && timedOut(ctx, runCtx) {
+			return e.stop(ctx, ErrExecutionTimeExceeded, steps, inputs)
+		}
+		if errors.Is(err, ErrUnableToParseOutput) {
+			steps, err = e.handleParserError(runCtx, steps, err)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -68,46 +144,208 @@ func (e Executor) Call(ctx context.Context, inputValues map[string]any, _ ...cha
 			return e.getReturn(finish, steps), nil
 		}
 
-		for _, action := range actions {
-			steps, err = e.doAction(ctx, steps, nameToTool, action)
+		newSteps, err := e.doActions(runCtx, nameToTool, actions)
+		if err != nil && timedOut(ctx, runCtx) {
+			return e.stop(ctx, ErrExecutionTimeExceeded, steps, inputs)
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, newSteps...)
+	}
+
+	return e.stop(ctx, ErrNotFinished, steps, inputs)
+}
+
+// doActions runs the actions and returns the steps in the order of the actions.
+// If the max concurrency of the executor is larger than one, up to that many
+// actions are run at the same time. The first error cancels the other actions.
+func (e Executor) doActions(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	steps := make([]schema.AgentStep, len(actions))
+	if e.MaxConcurrency <= 1 || len(actions) <= 1 {
+		for i, action := range actions {
+			step, err := e.doAction(ctx, nameToTool, action)
 			if err != nil {
 				return nil, err
 			}
+			steps[i] = step
 		}
+
+		return steps, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int, len(actions))
+	for i := range actions {
+		jobs <- i
+	}
+	close(jobs)
+
+	errs := make(chan error, len(actions))
+	numWorkers := e.MaxConcurrency
+	if numWorkers > len(actions) {
+		numWorkers = len(actions)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				step, err := e.doAction(ctx, nameToTool, actions[i])
+				if err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+				steps[i] = step
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	if err, ok := <-errs; ok {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return nil, ErrNotFinished
+	return steps, nil
 }
 
 func (e Executor) doAction(
 	ctx context.Context,
-	steps []schema.AgentStep,
 	nameToTool map[string]tools.Tool,
 	action schema.AgentAction,
-) ([]schema.AgentStep, error) {
-	if e.CallbacksHandler != nil {
-		e.CallbacksHandler.HandleAgentAction(ctx, action)
+) (schema.AgentStep, error) {
+	if handler := e.callbacksHandler(ctx); handler != nil {
+		handler.HandleAgentAction(ctx, action)
 	}
+	e.sendEvent(ctx, Event{Type: EventAction, Action: &action})
 
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		return schema.AgentStep{
 			Action:      action,
 			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+		}, nil
 	}
 
-	observation, err := tool.Call(ctx, action.ToolInput)
+	e.sendEvent(ctx, Event{Type: EventToolStart, Action: &action})
+	observation, err := e.runTool(ctx, tool, action)
 	if err != nil {
+		return schema.AgentStep{}, err
+	}
+	e.sendEvent(ctx, Event{Type: EventToolEnd, Action: &action, Observation: observation})
+
+	return schema.AgentStep{
+		Action:      action,
+		Observation: observation,
+	}, nil
+}
+
+// runTool calls the tool with the input of the action and returns the
+// observation. Invalid input and, depending on the tool error policy, errors
+// from the tool are turned into the observation. The call is reported to the
+// callbacks handlers as a tool run ending with the observation.
+func (e Executor) runTool(ctx context.Context, tool tools.Tool, action schema.AgentAction) (string, error) {
+	return tools.RunWithCallbacks(ctx, tool.Name(), e.CallbacksHandler, action.ToolInput,
+		func(ctx context.Context) (string, error) {
+			observation, err := callTool(ctx, tool, action.ToolInput)
+			if errors.Is(err, tools.ErrInvalidToolInput) {
+				return fmt.Sprintf("%s, fix the input and try again", err.Error()), nil
+			}
+			if err != nil {
+				if e.ToolErrorPolicy != ErrorAsObservation {
+					e.handleText(ctx, fmt.Sprintf("tool %s failed, stopping the agent: %s", action.Tool, err.Error()))
+					return "", err
+				}
+
+				e.handleText(ctx, fmt.Sprintf("tool %s failed, using the error as observation: %s", action.Tool, err.Error()))
+				return fmt.Sprintf("error from tool %s: %s", action.Tool, err.Error()), nil
+			}
+
+			return observation, nil
+		})
+}
+
+// handleParserError applies the parser error policy of the executor. With
+// ErrorAsObservation a step asking the agent to fix its output is added. The
+// log of the step is the raw output of the agent, if the error carries it.
+func (e Executor) handleParserError(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	err error,
+) ([]schema.AgentStep, error) {
+	if e.ParserErrorPolicy != ErrorAsObservation {
+		e.handleText(ctx, fmt.Sprintf("unable to parse agent output, stopping the agent: %s", err.Error()))
 		return nil, err
 	}
 
+	e.handleText(ctx, fmt.Sprintf("unable to parse agent output, asking the agent to retry: %s", err.Error()))
+	action := schema.AgentAction{
+		Tool:      _parserErrorTool,
+		ToolInput: _parserErrorObservation,
+	}
+	var parseErr *OutputParseError
+	if errors.As(err, &parseErr) {
+		action.Log = parseErr.Output
+	}
+	if handler := e.callbacksHandler(ctx); handler != nil {
+		handler.HandleAgentAction(ctx, action)
+	}
+
 	return append(steps, schema.AgentStep{
 		Action:      action,
-		Observation: observation,
+		Observation: _parserErrorObservation,
 	}), nil
 }
 
+func (e Executor) sendEvent(ctx context.Context, event Event) {
+	if e.emit != nil {
+		e.emit(ctx, event)
+	}
+}
+
+// callbacksHandler returns the handler the executor reports to within ctx.
+func (e Executor) callbacksHandler(ctx context.Context) callbacks.Handler { //nolint:ireturn
+	return callbacks.HandlerFor(ctx, e.CallbacksHandler)
+}
+
+func (e Executor) handleText(ctx context.Context, text string) {
+	if handler := e.callbacksHandler(ctx); handler != nil {
+		handler.HandleText(ctx, text)
+	}
+}
+
+// callTool calls the tool with the input. The input to structured tools is
+// validated against the schema of the tool before the tool is called.
+func callTool(ctx context.Context, tool tools.Tool, input string) (string, error) {
+	structuredTool, ok := tool.(tools.StructuredTool)
+	if !ok {
+		return tool.Call(ctx, input)
+	}
+
+	args, err := tools.ParseStructuredInput(structuredTool.Schema(), input)
+	if err != nil {
+		return "", err
+	}
+
+	return structuredTool.CallStructured(ctx, args)
+}
+
 func (e Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
 	if e.ReturnIntermediateSteps {
 		finish.ReturnValues[_intermediateStepsOutputKey] = steps
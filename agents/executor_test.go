@@ -2,13 +2,20 @@ package agents_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aresa7796/langchaingo/agents"
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/fake"
 	"github.com/aresa7796/langchaingo/llms/openai"
+	"github.com/aresa7796/langchaingo/schema"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/aresa7796/langchaingo/tools/serpapi"
 	"github.com/stretchr/testify/require"
@@ -44,3 +51,329 @@ func TestMRKL(t *testing.T) {
 
 	require.True(t, strings.Contains(result, "210"), "correct answer 210 not in response")
 }
+
+// testAgent returns the results of plan, which is called with the steps so far.
+type testAgent struct {
+	plan func(steps []schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error)
+}
+
+func (a testAgent) Plan(
+	_ context.Context,
+	steps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return a.plan(steps)
+}
+
+func (a testAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a testAgent) GetOutputKeys() []string { return []string{"output"} }
+
+// textRecorder records the texts and actions given to the callbacks handler.
+type textRecorder struct {
+	callbacks.SimpleHandler
+	texts   []string
+	actions []schema.AgentAction
+}
+
+func (r *textRecorder) HandleText(_ context.Context, text string) {
+	r.texts = append(r.texts, text)
+}
+
+func (r *textRecorder) HandleAgentAction(_ context.Context, action schema.AgentAction) {
+	r.actions = append(r.actions, action)
+}
+
+// failingTool is a tool that always returns an error.
+type failingTool struct{}
+
+func (failingTool) Name() string        { return "failing" }
+func (failingTool) Description() string { return "Always fails." }
+func (failingTool) Call(context.Context, string) (string, error) {
+	return "", errors.New("connection refused")
+}
+
+// blockingTool is a tool that blocks until its context is done.
+type blockingTool struct{}
+
+func (blockingTool) Name() string        { return "blocking" }
+func (blockingTool) Description() string { return "Never returns." }
+func (blockingTool) Call(ctx context.Context, _ string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+// llmAgent asks the llm for the input of the failing tool at each step.
+type llmAgent struct {
+	llm llms.LLM
+}
+
+func (a llmAgent) Plan(
+	ctx context.Context,
+	_ []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	text, err := a.llm.Call(ctx, inputs["input"])
+	if err != nil {
+		return nil, nil, err
+	}
+	return []schema.AgentAction{{Tool: "failing", ToolInput: text, Log: text}}, nil, nil
+}
+
+func (a llmAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a llmAgent) GetOutputKeys() []string { return []string{"output"} }
+
+func finishAfterSteps(n int) func([]schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return func(steps []schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		if len(steps) < n {
+			return []schema.AgentAction{{Tool: "failing", ToolInput: "x"}}, nil, nil
+		}
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": steps[n-1].Observation}}, nil
+	}
+}
+
+func TestExecutorToolErrorPolicy(t *testing.T) {
+	t.Parallel()
+
+	agent := testAgent{plan: finishAfterSteps(1)}
+
+	executor := agents.NewExecutor(agent, []tools.Tool{failingTool{}})
+	_, err := chains.Run(context.Background(), executor, "input")
+	require.EqualError(t, err, "connection refused")
+
+	recorder := &textRecorder{}
+	executor = agents.NewExecutor(agent, []tools.Tool{failingTool{}},
+		agents.WithToolErrorPolicy(agents.ErrorAsObservation),
+		agents.WithCallbacksHandler(recorder),
+	)
+	result, err := chains.Run(context.Background(), executor, "input")
+	require.NoError(t, err)
+	require.Equal(t, "error from tool failing: connection refused", result)
+	require.Len(t, recorder.texts, 1)
+	require.Contains(t, recorder.texts[0], "using the error as observation")
+}
+
+func TestExecutorParserErrorPolicy(t *testing.T) {
+	t.Parallel()
+
+	agent := testAgent{plan: func(steps []schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		if len(steps) == 0 {
+			return nil, nil, &agents.OutputParseError{Output: "I think I know"}
+		}
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": steps[0].Observation}}, nil
+	}}
+
+	executor := agents.NewExecutor(agent, nil)
+	_, err := chains.Run(context.Background(), executor, "input")
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+
+	recorder := &textRecorder{}
+	executor = agents.NewExecutor(agent, nil,
+		agents.WithParserErrorPolicy(agents.ErrorAsObservation),
+		agents.WithCallbacksHandler(recorder),
+	)
+	result, err := chains.Run(context.Background(), executor, "input")
+	require.NoError(t, err)
+	require.Contains(t, result, "Invalid format")
+	require.Len(t, recorder.actions, 1)
+	require.Equal(t, "I think I know", recorder.actions[0].Log)
+	require.Contains(t, recorder.texts[0], "asking the agent to retry")
+}
+
+// concurrencyTool records the max number of calls running at the same time and
+// returns its input reversed after a short wait.
+type concurrencyTool struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (t *concurrencyTool) Name() string        { return "reverse" }
+func (t *concurrencyTool) Description() string { return "Reverses the input." }
+func (t *concurrencyTool) Call(ctx context.Context, input string) (string, error) {
+	t.mu.Lock()
+	t.inFlight++
+	if t.inFlight > t.max {
+		t.max = t.inFlight
+	}
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.inFlight--
+		t.mu.Unlock()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	r := []rune(input)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r), nil
+}
+
+func TestExecutorMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{"ab", "cd", "ef", "gh", "ij"}
+	agent := testAgent{plan: func(steps []schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		if len(steps) > 0 {
+			return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done"}}, nil
+		}
+		actions := make([]schema.AgentAction, 0, len(inputs))
+		for _, input := range inputs {
+			actions = append(actions, schema.AgentAction{Tool: "reverse", ToolInput: input})
+		}
+		return actions, nil, nil
+	}}
+
+	tool := &concurrencyTool{}
+	executor := agents.NewExecutor(agent, []tools.Tool{tool},
+		agents.WithMaxConcurrency(2),
+		agents.WithReturnIntermediateSteps(),
+	)
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "input"})
+	require.NoError(t, err)
+	require.Equal(t, 2, tool.max)
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, len(inputs))
+	for i, step := range steps {
+		require.Equal(t, inputs[i], step.Action.ToolInput)
+	}
+	require.Equal(t, "ba", steps[0].Observation)
+	require.Equal(t, "ji", steps[4].Observation)
+
+	executor = agents.NewExecutor(agent, []tools.Tool{tool, failingTool{}}, agents.WithMaxConcurrency(3))
+	agent.plan = func([]schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		return []schema.AgentAction{
+			{Tool: "reverse", ToolInput: "ab"},
+			{Tool: "failing", ToolInput: "x"},
+			{Tool: "reverse", ToolInput: "cd"},
+		}, nil, nil
+	}
+	executor.Agent = agent
+	_, err = chains.Run(context.Background(), executor, "input")
+	require.EqualError(t, err, "connection refused")
+}
+
+func TestExecutorEarlyStopping(t *testing.T) {
+	t.Parallel()
+
+	agent := testAgent{plan: func([]schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		time.Sleep(5 * time.Millisecond)
+		return []schema.AgentAction{{Tool: "failing", ToolInput: "x"}}, nil, nil
+	}}
+	toolList := []tools.Tool{failingTool{}}
+	toolErrors := agents.WithToolErrorPolicy(agents.ErrorAsObservation)
+
+	executor := agents.NewExecutor(agent, toolList, toolErrors, agents.WithMaxIterations(2))
+	_, err := chains.Run(context.Background(), executor, "input")
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+
+	executor = agents.NewExecutor(agent, toolList, toolErrors, agents.WithMaxExecutionTime(time.Millisecond))
+	_, err = chains.Run(context.Background(), executor, "input")
+	require.ErrorIs(t, err, agents.ErrExecutionTimeExceeded)
+
+	executor = agents.NewExecutor(agent, toolList, toolErrors, agents.WithMaxTokens(1))
+	_, err = chains.Run(context.Background(), executor, "input")
+	require.ErrorIs(t, err, agents.ErrTokenBudgetExceeded)
+
+	// The max execution time cancels running tools.
+	blocking := testAgent{plan: func([]schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		return []schema.AgentAction{{Tool: "blocking", ToolInput: "x"}}, nil, nil
+	}}
+	executor = agents.NewExecutor(blocking, []tools.Tool{blockingTool{}},
+		agents.WithMaxExecutionTime(10*time.Millisecond),
+		agents.WithEarlyStoppingMethod(agents.EarlyStoppingForce),
+	)
+	output, err := chains.Run(context.Background(), executor, "input")
+	require.NoError(t, err)
+	require.Contains(t, output, "Agent stopped")
+
+	// The usage reported by the llm is counted rather than the short steps.
+	llm := fake.NewLLM(fake.WithResponses(
+		fake.Response{Text: "x", Usage: llms.NewUsage("test-model", 40, 1)},
+		fake.Response{Text: "x", Usage: llms.NewUsage("test-model", 40, 1)},
+	))
+	executor = agents.NewExecutor(llmAgent{llm: llm}, toolList, toolErrors, agents.WithMaxTokens(50))
+	_, err = chains.Run(context.Background(), executor, "input")
+	require.ErrorIs(t, err, agents.ErrTokenBudgetExceeded)
+	require.Len(t, llm.Calls(), 2)
+
+	executor = agents.NewExecutor(agent, toolList, toolErrors,
+		agents.WithMaxIterations(2),
+		agents.WithEarlyStoppingMethod(agents.EarlyStoppingForce),
+		agents.WithReturnIntermediateSteps(),
+	)
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "input"})
+	require.NoError(t, err)
+	require.Contains(t, result["output"], "Agent stopped")
+	require.Len(t, result["intermediateSteps"], 2)
+
+	// testAgent can not generate a final answer, so generate falls back to force.
+	executor = agents.NewExecutor(agent, toolList, toolErrors,
+		agents.WithMaxIterations(1),
+		agents.WithEarlyStoppingMethod(agents.EarlyStoppingGenerate),
+	)
+	output, err = chains.Run(context.Background(), executor, "input")
+	require.NoError(t, err)
+	require.Contains(t, output, "Agent stopped")
+}
+
+// toolRunRecorder records the tool runs reported to it.
+type toolRunRecorder struct {
+	callbacks.SimpleHandler
+	events []string
+}
+
+func (r *toolRunRecorder) HandleToolStart(ctx context.Context, input string) {
+	r.events = append(r.events, "start "+callbacks.RunName(ctx)+" "+input)
+}
+
+func (r *toolRunRecorder) HandleToolEnd(_ context.Context, output string) {
+	r.events = append(r.events, "end "+output)
+}
+
+func (r *toolRunRecorder) HandleToolError(_ context.Context, err error) {
+	r.events = append(r.events, "error "+err.Error())
+}
+
+func TestExecutorReportsToolRuns(t *testing.T) {
+	t.Parallel()
+
+	calculatorHandler := &toolRunRecorder{}
+	agent := testAgent{plan: func(steps []schema.AgentStep) ([]schema.AgentAction, *schema.AgentFinish, error) {
+		switch len(steps) {
+		case 0:
+			return []schema.AgentAction{{Tool: "failing", ToolInput: "x"}}, nil, nil
+		case 1:
+			return []schema.AgentAction{{Tool: "calculator", ToolInput: "1 + 1"}}, nil, nil
+		}
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": steps[1].Observation}}, nil
+	}}
+	executor := agents.NewExecutor(agent,
+		[]tools.Tool{failingTool{}, tools.Calculator{CallbacksHandler: calculatorHandler}},
+		agents.WithToolErrorPolicy(agents.ErrorAsObservation),
+	)
+
+	recorder := &toolRunRecorder{}
+	ctx := callbacks.WithHandlers(context.Background(), recorder)
+	output, err := chains.Run(ctx, executor, "input")
+	require.NoError(t, err)
+	require.Equal(t, "2", output)
+
+	// The failing tool does not report its runs itself, and the calculator's
+	// own run is not reported to the handlers of the context twice.
+	require.Equal(t, []string{
+		"start failing x",
+		"end error from tool failing: connection refused",
+		"start calculator 1 + 1",
+		"end 2",
+	}, recorder.events)
+	require.Equal(t, []string{"start calculator 1 + 1", "end 2"}, calculatorHandler.events)
+}
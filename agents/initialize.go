@@ -1,6 +1,8 @@
 package agents
 
 import (
+	"fmt"
+
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/tools"
 )
@@ -17,6 +19,10 @@ const (
 	// ConversationalReactDescription is an AgentType constant that represents
 	// the "conversationalReactDescription" agent type.
 	ConversationalReactDescription AgentType = "conversationalReactDescription"
+	// OpenAIFunctions is an AgentType constant that represents the
+	// "openAIFunctions" agent type. The LLM must implement llms.ChatLLM and
+	// support function calling.
+	OpenAIFunctions AgentType = "openAIFunctions"
 )
 
 // Initialize is a function that creates a new executor with the specified LLM
@@ -34,6 +40,12 @@ func Initialize(
 		agent = NewOneShotAgent(llm, tools, opts...)
 	case ConversationalReactDescription:
 		agent = NewConversationalAgent(llm, tools, opts...)
+	case OpenAIFunctions:
+		chatLLM, ok := llm.(llms.ChatLLM)
+		if !ok {
+			return Executor{}, fmt.Errorf("%w: %s requires a chat llm", ErrUnsupportedLLM, agentType)
+		}
+		agent = NewOpenAIFunctionsAgent(chatLLM, tools, opts...)
 	default:
 		return Executor{}, ErrUnknownAgentType
 	}
@@ -2,7 +2,6 @@ package agents
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -33,7 +32,10 @@ type OneShotZeroAgent struct {
 	OutputKey string
 }
 
-var _ Agent = (*OneShotZeroAgent)(nil)
+var (
+	_ Agent            = (*OneShotZeroAgent)(nil)
+	_ StoppedResponder = (*OneShotZeroAgent)(nil)
+)
 
 // NewOneShotAgent creates a new OneShotZeroAgent with the given LLM model, tools,
 // and options. It returns a pointer to the created agent. The opts parameter
@@ -69,7 +71,7 @@ func (a *OneShotZeroAgent) Plan(
 		ctx,
 		a.Chain,
 		fullInputs,
-		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
+		chainCallOptions(ctx, chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}))...,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -78,6 +80,29 @@ func (a *OneShotZeroAgent) Plan(
 	return a.parseOutput(output)
 }
 
+// StoppedResponse makes the agent give a final answer based on the intermediate
+// steps. It is used by the executor when the agent is stopped early.
+func (a *OneShotZeroAgent) StoppedResponse(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	fullInputs["agent_scratchpad"] = stoppedScratchPad(intermediateSteps)
+	fullInputs["today"] = time.Now().Format("January 02, 2006")
+
+	output, err := chains.Predict(ctx, a.Chain, fullInputs, chainCallOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return finishFromOutput(a.OutputKey, output, a.parseOutput), nil
+}
+
 func (a *OneShotZeroAgent) GetInputKeys() []string {
 	chainInputs := a.Chain.GetInputKeys()
 
@@ -112,7 +137,7 @@ func (a *OneShotZeroAgent) parseOutput(output string) ([]schema.AgentAction, *sc
 	r := regexp.MustCompile(`Action:\s*(.+)\s*Action Input:\s*(.+)`)
 	matches := r.FindStringSubmatch(output)
 	if len(matches) == 0 {
-		return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+		return nil, nil, &OutputParseError{Output: output}
 	}
 
 	return []schema.AgentAction{
@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/aresa7796/langchaingo/jsonschema"
 	"github.com/aresa7796/langchaingo/prompts"
 	"github.com/aresa7796/langchaingo/tools"
 )
@@ -57,11 +59,25 @@ func toolNames(tools []tools.Tool) string {
 	return tn.String()
 }
 
-func toolDescriptions(tools []tools.Tool) string {
+func toolDescriptions(t []tools.Tool) string {
 	var ts strings.Builder
-	for _, tool := range tools {
-		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tool.Description()))
+	for _, tool := range t {
+		ts.WriteString(fmt.Sprintf("- %s: %s", tool.Name(), tool.Description()))
+		if structuredTool, ok := tool.(tools.StructuredTool); ok {
+			ts.WriteString(fmt.Sprintf(" The input must be a JSON object matching this schema: %s",
+				schemaString(structuredTool.Schema())))
+		}
+		ts.WriteString("\n")
 	}
 
 	return ts.String()
 }
+
+func schemaString(schema jsonschema.Definition) string {
+	s, err := json.Marshal(schema)
+	if err != nil {
+		return ""
+	}
+
+	return string(s)
+}
@@ -0,0 +1,259 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/jsonschema"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/tools"
+)
+
+const (
+	_defaultOpenAIFunctionsSystemMessage = "You are a helpful AI assistant."
+	_openAIFunctionsToolInputKey         = "__arg1"
+)
+
+// OpenAIFunctionsAgent is an agent that uses the function calling feature of
+// chat models like the OpenAI chat models to decide what to do. Every tool is
+// described to the model as a function, so no parsing of free text is needed.
+type OpenAIFunctionsAgent struct {
+	// LLM is the chat model used by the agent. It must support llms.WithFunctions.
+	LLM llms.ChatLLM
+	// Prompt is the prompt formatted with the inputs. The messages of the
+	// intermediate steps are added after the messages of the prompt.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// Output key is the key where the final output is placed.
+	OutputKey string
+}
+
+var (
+	_ Agent            = (*OpenAIFunctionsAgent)(nil)
+	_ StoppedResponder = (*OpenAIFunctionsAgent)(nil)
+)
+
+// NewOpenAIFunctionsAgent creates a new OpenAIFunctionsAgent with the given chat
+// model, tools and options. The prompt prefix option sets the system message.
+func NewOpenAIFunctionsAgent(llm llms.ChatLLM, tools []tools.Tool, opts ...CreationOption) *OpenAIFunctionsAgent {
+	options := openAIFunctionsDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &OpenAIFunctionsAgent{
+		LLM:       llm,
+		Prompt:    options.getOpenAIFunctionsPrompt(),
+		Tools:     tools,
+		OutputKey: options.outputKey,
+	}
+}
+
+// Plan decides what action to take or returns the final result of the input.
+func (a *OpenAIFunctionsAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	promptValue, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := append(promptValue.Messages(), constructFunctionMessages(intermediateSteps)...)
+
+	result, err := a.LLM.Call(ctx, messages, llmCallOptions(ctx, llms.WithFunctions(a.functions()))...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.parseOutput(result)
+}
+
+// StoppedResponse makes the agent give a final answer based on the intermediate
+// steps. The model is called without functions so it has to answer in text.
+func (a *OpenAIFunctionsAgent) StoppedResponse(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	promptValue, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := append(promptValue.Messages(), constructFunctionMessages(intermediateSteps)...)
+	messages = append(messages, schema.HumanChatMessage{
+		Content: strings.TrimSpace(_stoppedResponseInstruction),
+	})
+
+	result, err := a.LLM.Call(ctx, messages, llmCallOptions(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{a.OutputKey: result.Content},
+		Log:          result.Content,
+	}, nil
+}
+
+func (a *OpenAIFunctionsAgent) GetInputKeys() []string {
+	return a.Prompt.GetInputVariables()
+}
+
+func (a *OpenAIFunctionsAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+// functions describes the tools of the agent as function definitions. Structured
+// tools use the schema of the tool, other tools take the input to the tool as a
+// single string argument.
+func (a *OpenAIFunctionsAgent) functions() []llms.FunctionDefinition {
+	functions := make([]llms.FunctionDefinition, 0, len(a.Tools))
+	for _, tool := range a.Tools {
+		parameters := jsonschema.Definition{
+			Type: jsonschema.Object,
+			Properties: map[string]jsonschema.Definition{
+				_openAIFunctionsToolInputKey: {Type: jsonschema.String},
+			},
+			Required: []string{_openAIFunctionsToolInputKey},
+		}
+		if structuredTool, ok := tool.(tools.StructuredTool); ok {
+			parameters = structuredTool.Schema()
+		}
+
+		functions = append(functions, llms.FunctionDefinition{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  parameters,
+		})
+	}
+
+	return functions
+}
+
+// isStructuredTool reports whether the agent has a structured tool with the name.
+func (a *OpenAIFunctionsAgent) isStructuredTool(name string) bool {
+	for _, tool := range a.Tools {
+		if _, ok := tool.(tools.StructuredTool); ok && strings.EqualFold(tool.Name(), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseOutput turns the function or tool calls of the message into actions. A
+// message calling several tools at once gives one action per call, which the
+// executor can run concurrently. A message without calls is the final answer.
+func (a *OpenAIFunctionsAgent) parseOutput(msg *schema.AIChatMessage) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if msg == nil {
+		return nil, nil, ErrUnableToParseOutput
+	}
+
+	calls := make([]*schema.FunctionCall, 0, len(msg.ToolCalls))
+	for _, toolCall := range msg.ToolCalls {
+		if toolCall.FunctionCall == nil {
+			return nil, nil, fmt.Errorf("%w: tool call %s without a function", ErrUnableToParseOutput, toolCall.ID)
+		}
+		calls = append(calls, toolCall.FunctionCall)
+	}
+	if len(calls) == 0 && msg.FunctionCall != nil {
+		calls = append(calls, msg.FunctionCall)
+	}
+
+	if len(calls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.OutputKey: msg.Content,
+			},
+			Log: msg.Content,
+		}, nil
+	}
+
+	actions := make([]schema.AgentAction, 0, len(calls))
+	for _, call := range calls {
+		// Structured tools get the arguments as is and validate them in the executor.
+		toolInput := call.Arguments
+		if !a.isStructuredTool(call.Name) {
+			toolInput = functionArgumentsToToolInput(toolInput)
+		}
+
+		actions = append(actions, schema.AgentAction{
+			Tool:      call.Name,
+			ToolInput: toolInput,
+			Log:       fmt.Sprintf("Invoking: `%s` with `%s`\n%s", call.Name, toolInput, msg.Content),
+		})
+	}
+
+	return actions, nil, nil
+}
+
+// constructFunctionMessages turns the intermediate steps into the function call
+// messages of the model followed by the observations as function messages.
+func constructFunctionMessages(steps []schema.AgentStep) []schema.ChatMessage {
+	messages := make([]schema.ChatMessage, 0, 2*len(steps))
+	for _, step := range steps {
+		messages = append(messages,
+			schema.AIChatMessage{
+				FunctionCall: &schema.FunctionCall{
+					Name:      step.Action.Tool,
+					Arguments: toolInputToFunctionArguments(step.Action.ToolInput),
+				},
+			},
+			schema.FunctionChatMessage{
+				Name:    step.Action.Tool,
+				Content: step.Observation,
+			},
+		)
+	}
+
+	return messages
+}
+
+// functionArgumentsToToolInput gets the tool input from the arguments of a
+// function call. If the arguments are not in the single string argument format
+// the raw arguments are used as the input.
+func functionArgumentsToToolInput(arguments string) string {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return arguments
+	}
+
+	if input, ok := args[_openAIFunctionsToolInputKey].(string); ok {
+		return input
+	}
+
+	return arguments
+}
+
+// toolInputToFunctionArguments is the inverse of functionArgumentsToToolInput.
+func toolInputToFunctionArguments(toolInput string) string {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(toolInput), &args); err == nil {
+		return toolInput
+	}
+
+	arguments, err := json.Marshal(map[string]string{_openAIFunctionsToolInputKey: toolInput})
+	if err != nil {
+		return toolInput
+	}
+
+	return string(arguments)
+}
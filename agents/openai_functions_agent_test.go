@@ -0,0 +1,221 @@
+package agents
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/jsonschema"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/openai"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+// testChatLLM returns the queued responses in order and records the messages
+// and options it was called with. The content of a response is streamed as one
+// chunk if a streaming func is given.
+type testChatLLM struct {
+	responses        []*schema.AIChatMessage
+	recordedMessages [][]schema.ChatMessage
+	recordedOptions  []llms.CallOptions
+}
+
+var _ llms.ChatLLM = &testChatLLM{}
+
+func (l *testChatLLM) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	l.recordedMessages = append(l.recordedMessages, messages)
+	l.recordedOptions = append(l.recordedOptions, opts)
+
+	response := l.responses[0]
+	l.responses = l.responses[1:]
+	if opts.StreamingFunc != nil && response != nil && response.Content != "" {
+		if err := opts.StreamingFunc(ctx, []byte(response.Content)); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func (l *testChatLLM) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	generations := make([]*llms.Generation, 0, len(messageSets))
+	for _, messages := range messageSets {
+		msg, err := l.Call(ctx, messages, options...)
+		if err != nil {
+			return nil, err
+		}
+		generations = append(generations, &llms.Generation{Text: msg.Content, Message: msg})
+	}
+	return generations, nil
+}
+
+func TestOpenAIFunctionsAgent(t *testing.T) {
+	t.Parallel()
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{
+		{FunctionCall: &schema.FunctionCall{Name: "calculator", Arguments: `{"__arg1": "3 * 4"}`}},
+		{Content: "The answer is 12."},
+	}}
+
+	agent := NewOpenAIFunctionsAgent(llm, []tools.Tool{tools.Calculator{}})
+	executor := NewExecutor(agent, agent.Tools, WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "What is 3 times 4?"})
+	require.NoError(t, err)
+	require.Equal(t, "The answer is 12.", result["output"])
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 1)
+	require.Equal(t, "3 * 4", steps[0].Action.ToolInput)
+	require.Equal(t, "12", steps[0].Observation)
+
+	require.Len(t, llm.recordedOptions[0].Functions, 1)
+	require.Equal(t, "calculator", llm.recordedOptions[0].Functions[0].Name)
+
+	require.Equal(t, []schema.ChatMessage{
+		schema.SystemChatMessage{Content: _defaultOpenAIFunctionsSystemMessage},
+		schema.HumanChatMessage{Content: "What is 3 times 4?"},
+		schema.AIChatMessage{FunctionCall: &schema.FunctionCall{Name: "calculator", Arguments: `{"__arg1":"3 * 4"}`}},
+		schema.FunctionChatMessage{Name: "calculator", Content: "12"},
+	}, llm.recordedMessages[1])
+}
+
+func TestOpenAIFunctionsAgentStructuredTool(t *testing.T) {
+	t.Parallel()
+
+	type weatherInput struct {
+		City string `json:"city"`
+	}
+	weatherSchema := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{"city": {Type: jsonschema.String}},
+		Required:   []string{"city"},
+	}
+	weather := tools.NewStructured("weather", "Gets the weather.", weatherSchema,
+		func(_ context.Context, input weatherInput) (string, error) {
+			return "sunny in " + input.City, nil
+		})
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{
+		{FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"town": "Oslo"}`}},
+		{FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"city": "Oslo"}`}},
+		{Content: "It is sunny."},
+	}}
+
+	agent := NewOpenAIFunctionsAgent(llm, []tools.Tool{weather})
+	executor := NewExecutor(agent, agent.Tools, WithReturnIntermediateSteps())
+
+	result, err := chains.Call(context.Background(), executor, map[string]any{"input": "Weather in Oslo?"})
+	require.NoError(t, err)
+
+	require.Equal(t, weatherSchema, llm.recordedOptions[0].Functions[0].Parameters)
+
+	steps, ok := result["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 2)
+	require.Equal(t, `{"town": "Oslo"}`, steps[0].Action.ToolInput)
+	require.Contains(t, steps[0].Observation, `missing required property "city"`)
+	require.Equal(t, "sunny in Oslo", steps[1].Observation)
+}
+
+func TestOpenAIFunctionsAgentStoppedResponse(t *testing.T) {
+	t.Parallel()
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{
+		{FunctionCall: &schema.FunctionCall{Name: "calculator", Arguments: `{"__arg1": "3 * 4"}`}},
+		{Content: "It is 12."},
+	}}
+
+	agent := NewOpenAIFunctionsAgent(llm, []tools.Tool{tools.Calculator{}})
+	executor := NewExecutor(agent, agent.Tools,
+		WithMaxIterations(1),
+		WithEarlyStoppingMethod(EarlyStoppingGenerate),
+	)
+
+	result, err := chains.Run(context.Background(), executor, "What is 3 times 4?")
+	require.NoError(t, err)
+	require.Equal(t, "It is 12.", result)
+
+	require.Empty(t, llm.recordedOptions[1].Functions)
+	lastMessages := llm.recordedMessages[1]
+	require.Len(t, lastMessages, 5)
+	require.Equal(t, schema.ChatMessageTypeHuman, lastMessages[4].GetType())
+}
+
+func TestOpenAIFunctionsAgentParseOutput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		input           *schema.AIChatMessage
+		expectedActions []schema.AgentAction
+		expectedFinish  *schema.AgentFinish
+	}{
+		{
+			input: &schema.AIChatMessage{Content: "done"},
+			expectedFinish: &schema.AgentFinish{
+				ReturnValues: map[string]any{"output": "done"},
+				Log:          "done",
+			},
+		},
+		{
+			input: &schema.AIChatMessage{
+				FunctionCall: &schema.FunctionCall{Name: "search", Arguments: `{"query": "go"}`},
+			},
+			expectedActions: []schema.AgentAction{{
+				Tool:      "search",
+				ToolInput: `{"query": "go"}`,
+				Log:       "Invoking: `search` with `{\"query\": \"go\"}`\n",
+			}},
+		},
+		{
+			input: &schema.AIChatMessage{
+				ToolCalls: []schema.ToolCall{
+					{ID: "a", FunctionCall: &schema.FunctionCall{Name: "search", Arguments: `{"__arg1": "go"}`}},
+					{ID: "b", FunctionCall: &schema.FunctionCall{Name: "calculator", Arguments: `{"__arg1": "1 + 1"}`}},
+				},
+			},
+			expectedActions: []schema.AgentAction{
+				{Tool: "search", ToolInput: "go", Log: "Invoking: `search` with `go`\n"},
+				{Tool: "calculator", ToolInput: "1 + 1", Log: "Invoking: `calculator` with `1 + 1`\n"},
+			},
+		},
+	}
+
+	a := OpenAIFunctionsAgent{OutputKey: "output"}
+	for _, tc := range testCases {
+		actions, finish, err := a.parseOutput(tc.input)
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedActions, actions)
+		require.Equal(t, tc.expectedFinish, finish)
+	}
+
+	_, _, err := a.parseOutput(&schema.AIChatMessage{ToolCalls: []schema.ToolCall{{ID: "a"}}})
+	require.ErrorIs(t, err, ErrUnableToParseOutput)
+}
+
+func TestOpenAIFunctionsAgentWithOpenAI(t *testing.T) {
+	t.Parallel()
+
+	if openaiKey := os.Getenv("OPENAI_API_KEY"); openaiKey == "" {
+		t.Skip("OPENAI_API_KEY not set")
+	}
+
+	llm, err := openai.NewChat()
+	require.NoError(t, err)
+
+	executor, err := Initialize(llm, []tools.Tool{tools.Calculator{}}, OpenAIFunctions)
+	require.NoError(t, err)
+
+	result, err := chains.Run(context.Background(), executor, "What is 1987 times 34?")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(result, "67558") || strings.Contains(result, "67,558"),
+		"result does not contain the correct answer '67558'")
+}
@@ -1,6 +1,8 @@
 package agents
 
 import (
+	"time"
+
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/memory"
 	"github.com/aresa7796/langchaingo/prompts"
@@ -18,6 +20,12 @@ type CreationOptions struct {
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
+	earlyStoppingMethod     EarlyStoppingMethod
+	maxExecutionTime        time.Duration
+	maxTokens               int
+	maxConcurrency          int
+	toolErrorPolicy         ErrorPolicy
+	parserErrorPolicy       ErrorPolicy
 }
 
 // CreationOption is a function type that can be used to modify the creation of the agents
@@ -50,6 +58,13 @@ func conversationalDefaultOptions() CreationOptions {
 	}
 }
 
+func openAIFunctionsDefaultOptions() CreationOptions {
+	return CreationOptions{
+		promptPrefix: _defaultOpenAIFunctionsSystemMessage,
+		outputKey:    _defaultOutputKey,
+	}
+}
+
 func (co CreationOptions) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
@@ -76,6 +91,18 @@ func (co CreationOptions) getConversationalPrompt(tools []tools.Tool) prompts.Pr
 	)
 }
 
+func (co CreationOptions) getOpenAIFunctionsPrompt() prompts.FormatPrompter {
+	system := prompts.NewSystemMessagePromptTemplate(co.promptPrefix, nil)
+	if co.prompt.Template != "" {
+		system = prompts.SystemMessagePromptTemplate{Prompt: co.prompt}
+	}
+
+	return prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+		system,
+		prompts.NewHumanMessagePromptTemplate("{{.input}}", []string{"input"}),
+	})
+}
+
 // WithMaxIterations is an option for setting the max number of iterations the executor
 // will complete.
 func WithMaxIterations(iterations int) CreationOption {
@@ -139,3 +166,57 @@ func WithCallbacksHandler(handler callbacks.Handler) CreationOption {
 		co.callbacksHandler = handler
 	}
 }
+
+// WithEarlyStoppingMethod is an option for setting what the executor returns when
+// the agent is stopped before giving a final answer.
+func WithEarlyStoppingMethod(method EarlyStoppingMethod) CreationOption {
+	return func(co *CreationOptions) {
+		co.earlyStoppingMethod = method
+	}
+}
+
+// WithMaxExecutionTime is an option for setting the max wall-clock time of a run
+// of the executor. Llm and tool calls still running when it is exceeded are
+// canceled through their context.
+func WithMaxExecutionTime(d time.Duration) CreationOption {
+	return func(co *CreationOptions) {
+		co.maxExecutionTime = d
+	}
+}
+
+// WithMaxTokens is an option for setting the token budget of a run of the
+// executor. The usage the llms of the run report is counted. If they report
+// none, the tokens of the outputs of the agent and of the observations are
+// counted with the tokenizer of gpt-3.5-turbo instead.
+func WithMaxTokens(maxTokens int) CreationOption {
+	return func(co *CreationOptions) {
+		co.maxTokens = maxTokens
+	}
+}
+
+// WithMaxConcurrency is an option for running the actions the agent returns in one
+// step concurrently, with at most maxConcurrency actions running at the same time.
+// The intermediate steps keep the order of the actions. The tools and the
+// callbacks handler must be safe for concurrent use.
+func WithMaxConcurrency(maxConcurrency int) CreationOption {
+	return func(co *CreationOptions) {
+		co.maxConcurrency = maxConcurrency
+	}
+}
+
+// WithToolErrorPolicy is an option for setting what the executor does when a tool
+// returns an error. The default is FailFast.
+func WithToolErrorPolicy(policy ErrorPolicy) CreationOption {
+	return func(co *CreationOptions) {
+		co.toolErrorPolicy = policy
+	}
+}
+
+// WithParserErrorPolicy is an option for setting what the executor does when the
+// agent is unable to parse the output of the llm. With ErrorAsObservation the
+// agent is asked to correct the format of its output. The default is FailFast.
+func WithParserErrorPolicy(policy ErrorPolicy) CreationOption {
+	return func(co *CreationOptions) {
+		co.parserErrorPolicy = policy
+	}
+}
@@ -0,0 +1,132 @@
+package agents
+
+import (
+	"context"
+
+	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// EventType is the type of an event of a streamed executor run.
+type EventType string
+
+const (
+	// EventAction is sent for every action planned by the agent.
+	EventAction EventType = "action"
+	// EventToolStart is sent before a tool is called.
+	EventToolStart EventType = "tool_start"
+	// EventToolEnd is sent after a tool returned, with the observation.
+	EventToolEnd EventType = "tool_end"
+	// EventLLMToken is sent for every chunk streamed by the llm of the agent.
+	EventLLMToken EventType = "llm_token"
+	// EventFinish is the last event of a successful run, with the outputs.
+	EventFinish EventType = "finish"
+	// EventError is the last event of a failed run, with the error.
+	EventError EventType = "error"
+)
+
+// Event is an event of a streamed executor run. Only the fields for the type of
+// the event are set.
+type Event struct {
+	Type EventType
+	// Action is set for EventAction, EventToolStart and EventToolEnd.
+	Action *schema.AgentAction
+	// Observation is the output of the tool for EventToolEnd.
+	Observation string
+	// Chunk is the chunk streamed by the llm for EventLLMToken.
+	Chunk []byte
+	// Outputs are the outputs of the executor for EventFinish.
+	Outputs map[string]any
+	// Err is the error of the run for EventError.
+	Err error
+}
+
+// Stream runs the executor in a new goroutine and sends the events of the run on
+// the returned channel. The channel is closed after the EventFinish or
+// EventError event. The caller must read the channel until it is closed or
+// cancel the context. Streaming of llm tokens requires an llm that supports
+// llms.WithStreamingFunc.
+func (e Executor) Stream(ctx context.Context, inputValues map[string]any) <-chan Event {
+	events := make(chan Event)
+
+	e.emit = func(ctx context.Context, event Event) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+	ctx = withStreamingFunc(ctx, func(ctx context.Context, chunk []byte) error {
+		e.emit(ctx, Event{Type: EventLLMToken, Chunk: chunk})
+		return ctx.Err()
+	})
+
+	go func() {
+		defer close(events)
+
+		outputs, err := chains.Call(ctx, e, inputValues)
+		if err != nil {
+			e.emit(ctx, Event{Type: EventError, Err: err})
+			return
+		}
+		e.emit(ctx, Event{Type: EventFinish, Outputs: outputs})
+	}()
+
+	return events
+}
+
+// EventIterator iterates over the events of a streamed executor run.
+type EventIterator struct {
+	events <-chan Event
+	event  Event
+}
+
+// StreamIterator is like Stream but returns an iterator over the events.
+func (e Executor) StreamIterator(ctx context.Context, inputValues map[string]any) *EventIterator {
+	return &EventIterator{events: e.Stream(ctx, inputValues)}
+}
+
+// Next advances the iterator to the next event. It returns false when there are
+// no more events.
+func (it *EventIterator) Next() bool {
+	event, ok := <-it.events
+	it.event = event
+	return ok
+}
+
+// Event returns the current event of the iterator.
+func (it *EventIterator) Event() Event {
+	return it.event
+}
+
+type streamingFuncKey struct{}
+
+// withStreamingFunc returns a context carrying the streaming func agents pass
+// to their llm.
+func withStreamingFunc(ctx context.Context, f func(context.Context, []byte) error) context.Context {
+	return context.WithValue(ctx, streamingFuncKey{}, f)
+}
+
+// streamingFuncFromContext returns the streaming func of the context, or nil.
+func streamingFuncFromContext(ctx context.Context) func(context.Context, []byte) error {
+	f, _ := ctx.Value(streamingFuncKey{}).(func(context.Context, []byte) error)
+	return f
+}
+
+// chainCallOptions adds the streaming func of the context, if any, to the options.
+func chainCallOptions(ctx context.Context, options ...chains.ChainCallOption) []chains.ChainCallOption {
+	if f := streamingFuncFromContext(ctx); f != nil {
+		options = append(options, chains.WithStreamingFunc(f))
+	}
+
+	return options
+}
+
+// llmCallOptions adds the streaming func of the context, if any, to the options.
+func llmCallOptions(ctx context.Context, options ...llms.CallOption) []llms.CallOption {
+	if f := streamingFuncFromContext(ctx); f != nil {
+		options = append(options, llms.WithStreamingFunc(f))
+	}
+
+	return options
+}
@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{
+		{FunctionCall: &schema.FunctionCall{Name: "calculator", Arguments: `{"__arg1": "3 * 4"}`}},
+		{Content: "The answer is 12."},
+	}}
+	agent := NewOpenAIFunctionsAgent(llm, []tools.Tool{tools.Calculator{}})
+	executor := NewExecutor(agent, agent.Tools)
+
+	var events []Event
+	it := executor.StreamIterator(context.Background(), map[string]any{"input": "What is 3 times 4?"})
+	for it.Next() {
+		events = append(events, it.Event())
+	}
+
+	types := make([]EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	require.Equal(t, []EventType{EventAction, EventToolStart, EventToolEnd, EventLLMToken, EventFinish}, types)
+
+	require.Equal(t, "calculator", events[0].Action.Tool)
+	require.Equal(t, "12", events[2].Observation)
+	require.Equal(t, "The answer is 12.", string(events[3].Chunk))
+	require.Equal(t, "The answer is 12.", events[4].Outputs["output"])
+}
+
+func TestExecutorStreamError(t *testing.T) {
+	t.Parallel()
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{nil}}
+	agent := NewOpenAIFunctionsAgent(llm, nil)
+
+	var last Event
+	for event := range NewExecutor(agent, nil).Stream(context.Background(), map[string]any{"input": "hi"}) {
+		last = event
+	}
+	require.Equal(t, EventError, last.Type)
+	require.ErrorIs(t, last.Err, ErrUnableToParseOutput)
+}
+
+func TestExecutorStreamCancel(t *testing.T) {
+	t.Parallel()
+
+	llm := &testChatLLM{responses: []*schema.AIChatMessage{{Content: "done"}}}
+	agent := NewOpenAIFunctionsAgent(llm, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := NewExecutor(agent, nil).Stream(ctx, map[string]any{"input": "hi"})
+	cancel()
+
+	// The run stops without the events being read, and the channel is closed.
+	for range events { //nolint:revive
+	}
+}
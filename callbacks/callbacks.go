@@ -8,21 +8,43 @@ import (
 )
 
 // Handler is the interface that allows for hooking into specific parts of an
-// LLM application.
+// LLM application. The context of each call holds the id of the run the event
+// belongs to, see RunID and ParentRunID.
 type Handler interface {
 	HandleText(ctx context.Context, text string)
 	HandleLLMStart(ctx context.Context, prompts []string)
+	HandleLLMNewToken(ctx context.Context, token string)
 	HandleLLMEnd(ctx context.Context, output llms.LLMResult)
+	HandleLLMError(ctx context.Context, err error)
 	HandleChainStart(ctx context.Context, inputs map[string]any)
 	HandleChainEnd(ctx context.Context, outputs map[string]any)
+	HandleChainError(ctx context.Context, err error)
 	HandleToolStart(ctx context.Context, input string)
 	HandleToolEnd(ctx context.Context, output string)
+	HandleToolError(ctx context.Context, err error)
 	HandleAgentAction(ctx context.Context, action schema.AgentAction)
 	HandleRetrieverStart(ctx context.Context, query string)
 	HandleRetrieverEnd(ctx context.Context, documents []schema.Document)
+	HandleRetrieverError(ctx context.Context, err error)
 }
 
 // HandlerHaver is an interface used to get callbacks handler.
 type HandlerHaver interface {
 	GetCallbackHandler() Handler
 }
+
+// StreamingFunc returns a streaming func that reports each chunk to the handler
+// as a new token before passing it on to next. It returns next as is if the
+// handler or next is nil, as calls without a streaming func do not stream.
+func StreamingFunc(
+	handler Handler,
+	next func(ctx context.Context, chunk []byte) error,
+) func(ctx context.Context, chunk []byte) error {
+	if handler == nil || next == nil {
+		return next
+	}
+	return func(ctx context.Context, chunk []byte) error {
+		handler.HandleLLMNewToken(ctx, string(chunk))
+		return next(ctx, chunk)
+	}
+}
@@ -0,0 +1,127 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// CombiningHandler is a callback handler that passes each event on to all of
+// its handlers, in order.
+type CombiningHandler struct {
+	Callbacks []Handler
+}
+
+var _ Handler = CombiningHandler{}
+
+// Combining returns a handler passing the events on to the given handlers. Nil
+// handlers are skipped. It returns nil if no handler is left, and the handler
+// itself if only one is.
+func Combining(handlers ...Handler) Handler { //nolint:ireturn
+	callbacks := make([]Handler, 0, len(handlers))
+	for _, h := range handlers {
+		if h != nil {
+			callbacks = append(callbacks, h)
+		}
+	}
+
+	switch len(callbacks) {
+	case 0:
+		return nil
+	case 1:
+		return callbacks[0]
+	default:
+		return CombiningHandler{Callbacks: callbacks}
+	}
+}
+
+func (l CombiningHandler) HandleText(ctx context.Context, text string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleText(ctx, text)
+	}
+}
+
+func (l CombiningHandler) HandleLLMStart(ctx context.Context, prompts []string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleLLMStart(ctx, prompts)
+	}
+}
+
+func (l CombiningHandler) HandleLLMNewToken(ctx context.Context, token string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleLLMNewToken(ctx, token)
+	}
+}
+
+func (l CombiningHandler) HandleLLMEnd(ctx context.Context, output llms.LLMResult) {
+	for _, handle := range l.Callbacks {
+		handle.HandleLLMEnd(ctx, output)
+	}
+}
+
+func (l CombiningHandler) HandleLLMError(ctx context.Context, err error) {
+	for _, handle := range l.Callbacks {
+		handle.HandleLLMError(ctx, err)
+	}
+}
+
+func (l CombiningHandler) HandleChainStart(ctx context.Context, inputs map[string]any) {
+	for _, handle := range l.Callbacks {
+		handle.HandleChainStart(ctx, inputs)
+	}
+}
+
+func (l CombiningHandler) HandleChainEnd(ctx context.Context, outputs map[string]any) {
+	for _, handle := range l.Callbacks {
+		handle.HandleChainEnd(ctx, outputs)
+	}
+}
+
+func (l CombiningHandler) HandleChainError(ctx context.Context, err error) {
+	for _, handle := range l.Callbacks {
+		handle.HandleChainError(ctx, err)
+	}
+}
+
+func (l CombiningHandler) HandleToolStart(ctx context.Context, input string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleToolStart(ctx, input)
+	}
+}
+
+func (l CombiningHandler) HandleToolEnd(ctx context.Context, output string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleToolEnd(ctx, output)
+	}
+}
+
+func (l CombiningHandler) HandleToolError(ctx context.Context, err error) {
+	for _, handle := range l.Callbacks {
+		handle.HandleToolError(ctx, err)
+	}
+}
+
+func (l CombiningHandler) HandleAgentAction(ctx context.Context, action schema.AgentAction) {
+	for _, handle := range l.Callbacks {
+		handle.HandleAgentAction(ctx, action)
+	}
+}
+
+func (l CombiningHandler) HandleRetrieverStart(ctx context.Context, query string) {
+	for _, handle := range l.Callbacks {
+		handle.HandleRetrieverStart(ctx, query)
+	}
+}
+
+func (l CombiningHandler) HandleRetrieverEnd(ctx context.Context, documents []schema.Document) {
+	for _, handle := range l.Callbacks {
+		handle.HandleRetrieverEnd(ctx, documents)
+	}
+}
+
+func (l CombiningHandler) HandleRetrieverError(ctx context.Context, err error) {
+	for _, handle := range l.Callbacks {
+		handle.HandleRetrieverError(ctx, err)
+	}
+}
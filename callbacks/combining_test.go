@@ -0,0 +1,66 @@
+package callbacks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder records the names of the events it gets.
+type eventRecorder struct {
+	SimpleHandler
+	name   string
+	events *[]string
+}
+
+func (r eventRecorder) HandleText(_ context.Context, text string) {
+	*r.events = append(*r.events, r.name+":"+text)
+}
+
+func (r eventRecorder) HandleToolError(_ context.Context, err error) {
+	*r.events = append(*r.events, r.name+":"+err.Error())
+}
+
+func TestCombining(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, Combining())
+	require.Nil(t, Combining(nil, nil))
+
+	var events []string
+	a := eventRecorder{name: "a", events: &events}
+	b := eventRecorder{name: "b", events: &events}
+	require.Equal(t, a, Combining(nil, a))
+
+	h := Combining(a, nil, b)
+	h.HandleText(context.Background(), "hi")
+	h.HandleToolError(context.Background(), errors.New("failed"))
+	h.HandleLLMStart(context.Background(), []string{"ignored"})
+	require.Equal(t, []string{"a:hi", "b:hi", "a:failed", "b:failed"}, events)
+}
+
+func TestHandlerFor(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+	own := eventRecorder{name: "own", events: &events}
+	a := eventRecorder{name: "a", events: &events}
+	b := eventRecorder{name: "b", events: &events}
+
+	ctx := context.Background()
+	require.Nil(t, HandlerFromContext(ctx))
+	require.Nil(t, HandlerFor(ctx, nil))
+	require.Equal(t, own, HandlerFor(ctx, own))
+
+	ctx = WithHandlers(ctx, a)
+	inner := WithHandlers(ctx, b)
+	HandlerFor(inner, own).HandleText(inner, "hi")
+	require.Equal(t, []string{"own:hi", "a:hi", "b:hi"}, events)
+
+	// Adding handlers to a context does not change the parent context.
+	events = nil
+	HandlerFromContext(ctx).HandleText(ctx, "hi")
+	require.Equal(t, []string{"a:hi"}, events)
+}
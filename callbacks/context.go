@@ -0,0 +1,40 @@
+package callbacks
+
+import "context"
+
+// handlersKey is the context key of the handlers of a context.
+type handlersKey struct{}
+
+// WithHandlers returns a context whose LLM calls, chains, tools and retrievers
+// report to the handlers, in addition to the handlers of ctx and their own
+// CallbacksHandler field. Do not also set a handler of the context in those
+// fields, or it gets each event twice.
+func WithHandlers(ctx context.Context, handlers ...Handler) context.Context {
+	existing, _ := ctx.Value(handlersKey{}).([]Handler)
+	combined := make([]Handler, 0, len(existing)+len(handlers))
+	combined = append(combined, existing...)
+	for _, h := range handlers {
+		if h != nil {
+			combined = append(combined, h)
+		}
+	}
+	return context.WithValue(ctx, handlersKey{}, combined)
+}
+
+// HandlerFromContext returns the handlers of the context combined, or nil if
+// the context has none.
+func HandlerFromContext(ctx context.Context) Handler { //nolint:ireturn
+	handlers, _ := ctx.Value(handlersKey{}).([]Handler)
+	return Combining(handlers...)
+}
+
+// HandlerFor returns the handler a component with the given own handler reports
+// to within ctx: its own handler combined with the handlers of the context. It
+// returns nil if there are none.
+func HandlerFor(ctx context.Context, own Handler) Handler { //nolint:ireturn
+	handlers, _ := ctx.Value(handlersKey{}).([]Handler)
+	if len(handlers) == 0 {
+		return own
+	}
+	return Combining(append([]Handler{own}, handlers...)...)
+}
@@ -0,0 +1,179 @@
+package callbacks
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aresa7796/langchaingo/llms"
+)
+
+// tokensPerPriceUnit is the number of tokens a price is given for.
+const tokensPerPriceUnit = 1000
+
+// Price is the price of a model per thousand tokens, in a currency of your
+// choice.
+type Price struct {
+	Prompt     float64 `json:"prompt"`
+	Completion float64 `json:"completion"`
+}
+
+// Cost returns the cost of the usage at the price.
+func (p Price) Cost(usage llms.Usage) float64 {
+	return (float64(usage.PromptTokens)*p.Prompt + float64(usage.CompletionTokens)*p.Completion) / tokensPerPriceUnit
+}
+
+// Pricer looks up the price of a model.
+type Pricer interface {
+	Price(model string) (Price, bool)
+}
+
+// PriceTable is a Pricer that maps model names to their prices. A model without
+// an entry of its own uses the entry of its longest prefix, so an entry for
+// "gpt-4" also prices "gpt-4-0613".
+type PriceTable map[string]Price
+
+var _ Pricer = PriceTable{}
+
+// Price returns the price of the model.
+func (t PriceTable) Price(model string) (Price, bool) {
+	if price, ok := t[model]; ok {
+		return price, true
+	}
+
+	longest := ""
+	for prefix := range t {
+		if len(prefix) > len(longest) && strings.HasPrefix(model, prefix) {
+			longest = prefix
+		}
+	}
+	if longest == "" {
+		return Price{}, false
+	}
+	return t[longest], true
+}
+
+// ModelUsage is the usage of a model aggregated by a CostHandler.
+type ModelUsage struct {
+	// Model is the name of the model, empty if the provider did not report it.
+	Model string
+	// Calls is the number of calls to the model.
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	// Cost is the estimated cost of the calls.
+	Cost float64
+	// Priced is false if the pricer has no price for the model, in which case
+	// the cost is zero.
+	Priced bool
+}
+
+// CostHandler is a callback handler that aggregates the token usage and the
+// estimated cost of LLM calls per model. Results without usage, such as cache
+// hits, are not counted. Use one handler per run to get the cost of that run.
+// It is safe for concurrent use.
+type CostHandler struct {
+	SimpleHandler
+
+	pricer Pricer
+
+	mu    sync.Mutex
+	usage map[string]*ModelUsage
+}
+
+var _ Handler = (*CostHandler)(nil)
+
+// NewCostHandler returns a handler pricing the usage with the pricer. A nil
+// pricer only aggregates the usage.
+func NewCostHandler(pricer Pricer) *CostHandler {
+	if pricer == nil {
+		pricer = PriceTable{}
+	}
+	return &CostHandler{
+		pricer: pricer,
+		usage:  make(map[string]*ModelUsage),
+	}
+}
+
+// Usage returns the usage of each model so far, sorted by model name.
+func (h *CostHandler) Usage() []ModelUsage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	usage := make([]ModelUsage, 0, len(h.usage))
+	for _, u := range h.usage {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Model < usage[j].Model })
+	return usage
+}
+
+// TotalCost returns the estimated cost of all the calls so far.
+func (h *CostHandler) TotalCost() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total := 0.0
+	for _, u := range h.usage {
+		total += u.Cost
+	}
+	return total
+}
+
+// Reset forgets the usage aggregated so far.
+func (h *CostHandler) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.usage = make(map[string]*ModelUsage)
+}
+
+// HandleLLMEnd adds the usage of the result to its models.
+func (h *CostHandler) HandleLLMEnd(_ context.Context, output llms.LLMResult) {
+	if output.Usage == nil {
+		return
+	}
+
+	// Attribute the usage of each call to its model. Generations of one call
+	// share a usage, which is counted once.
+	var usages []*llms.Usage
+	seen := make(map[*llms.Usage]bool)
+	for _, generations := range output.Generations {
+		for _, g := range generations {
+			if g == nil || g.Usage == nil || seen[g.Usage] {
+				continue
+			}
+			seen[g.Usage] = true
+			usages = append(usages, g.Usage)
+		}
+	}
+	if len(usages) == 0 {
+		usages = []*llms.Usage{output.Usage}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, usage := range usages {
+		h.add(*usage)
+	}
+}
+
+func (h *CostHandler) add(usage llms.Usage) {
+	u, ok := h.usage[usage.Model]
+	if !ok {
+		u = &ModelUsage{Model: usage.Model}
+		h.usage[usage.Model] = u
+	}
+
+	u.Calls++
+	u.PromptTokens += usage.PromptTokens
+	u.CompletionTokens += usage.CompletionTokens
+	u.TotalTokens += usage.TotalTokens
+
+	price, ok := h.pricer.Price(usage.Model)
+	u.Priced = ok
+	u.Cost += price.Cost(usage)
+}
@@ -0,0 +1,66 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceTable(t *testing.T) {
+	t.Parallel()
+
+	table := PriceTable{
+		"gpt-4":     {Prompt: 0.03, Completion: 0.06},
+		"gpt-4-32k": {Prompt: 0.06, Completion: 0.12},
+	}
+
+	price, ok := table.Price("gpt-4-0613")
+	require.True(t, ok)
+	require.Equal(t, table["gpt-4"], price)
+
+	price, ok = table.Price("gpt-4-32k-0613")
+	require.True(t, ok)
+	require.Equal(t, table["gpt-4-32k"], price)
+
+	_, ok = table.Price("claude-2.1")
+	require.False(t, ok)
+}
+
+func TestCostHandler(t *testing.T) {
+	t.Parallel()
+
+	h := NewCostHandler(PriceTable{
+		"gpt-4": {Prompt: 0.03, Completion: 0.06},
+	})
+	ctx := context.Background()
+
+	// Two choices of one call share the usage.
+	shared := llms.NewUsage("gpt-4-0613", 1000, 500)
+	h.HandleLLMEnd(ctx, llms.NewLLMResult([]*llms.Generation{
+		{Text: "a", Usage: shared},
+		{Text: "b", Usage: shared},
+	}))
+	h.HandleLLMEnd(ctx, llms.NewLLMResult([]*llms.Generation{
+		{Text: "c", Usage: llms.NewUsage("claude-2.1", 10, 20)},
+		{Text: "d", Usage: llms.NewUsage("gpt-4-0613", 1000, 0)},
+	}))
+	// Cache hits and providers without usage are not counted.
+	h.HandleLLMEnd(ctx, llms.LLMResult{
+		Generations: [][]*llms.Generation{{{Text: "e", Usage: shared}}},
+	})
+	h.HandleLLMEnd(ctx, llms.NewLLMResult([]*llms.Generation{{Text: "f"}}))
+
+	require.Equal(t, []ModelUsage{
+		{Model: "claude-2.1", Calls: 1, PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30},
+		{
+			Model: "gpt-4-0613", Calls: 2, PromptTokens: 2000, CompletionTokens: 500, TotalTokens: 2500,
+			Cost: 0.09, Priced: true,
+		},
+	}, h.Usage())
+	require.InDelta(t, 0.09, h.TotalCost(), 1e-9)
+
+	h.Reset()
+	require.Empty(t, h.Usage())
+}
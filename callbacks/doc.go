@@ -1,4 +1,23 @@
 // Package callbacks includes a standard interface for hooking into various
 // stages of your LLM application. The package contains an implementation of
-// this interface that prints to the standard output.
+// this interface that prints to the standard output, one that aggregates the
+// token usage and cost of LLM calls per model, one that passes events on to
+// several handlers, and a no-op SimpleHandler to embed in handlers that only
+// need some of the events.
+//
+// RecordHandler writes every event as a structured Record, with its time and
+// run ids, as JSON lines or to a slog logger. Replay reads such records back
+// and reconstructs the run tree, to debug a failed run after the fact.
+//
+// Every LLM call, chain, tool and retriever run gets a run id, carried by the
+// context of its events. Runs started within another run record it as their
+// parent, so the LLM calls of a chain can be attributed to it.
+//
+// Components report to the handler in their CallbacksHandler field and to the
+// handlers attached to the context with WithHandlers. Attaching handlers to the
+// context of a run makes every LLM call, chain, tool and retriever within it
+// report to them. Use Combining to fan out events to several handlers.
+//
+// The opentelemetry subpackage provides a handler that traces runs as
+// OpenTelemetry spans.
 package callbacks
@@ -23,10 +23,18 @@ func (l LogHandler) HandleLLMStart(_ context.Context, prompts []string) {
 	fmt.Println("Entering LLM with prompts:", prompts)
 }
 
+func (l LogHandler) HandleLLMNewToken(_ context.Context, token string) {
+	fmt.Print(token)
+}
+
 func (l LogHandler) HandleLLMEnd(_ context.Context, output llms.LLMResult) {
 	fmt.Println("Exiting LLM with results:", formatLLMResult(output))
 }
 
+func (l LogHandler) HandleLLMError(_ context.Context, err error) {
+	fmt.Println("Exiting LLM with error:", err)
+}
+
 func (l LogHandler) HandleChainStart(_ context.Context, inputs map[string]any) {
 	fmt.Println("Entering chain with inputs:", formatChainValues(inputs))
 }
@@ -35,6 +43,10 @@ func (l LogHandler) HandleChainEnd(_ context.Context, outputs map[string]any) {
 	fmt.Println("Exiting chain with outputs:", formatChainValues(outputs))
 }
 
+func (l LogHandler) HandleChainError(_ context.Context, err error) {
+	fmt.Println("Exiting chain with error:", err)
+}
+
 func (l LogHandler) HandleToolStart(_ context.Context, input string) {
 	fmt.Println("Entering tool with input:", removeNewLines(input))
 }
@@ -43,6 +55,10 @@ func (l LogHandler) HandleToolEnd(_ context.Context, output string) {
 	fmt.Println("Exiting tool with output:", removeNewLines(output))
 }
 
+func (l LogHandler) HandleToolError(_ context.Context, err error) {
+	fmt.Println("Exiting tool with error:", err)
+}
+
 func (l LogHandler) HandleAgentAction(_ context.Context, action schema.AgentAction) {
 	fmt.Println("Agent selected action:", formatAgentAction(action))
 }
@@ -55,6 +71,10 @@ func (l LogHandler) HandleRetrieverEnd(_ context.Context, documents []schema.Doc
 	fmt.Println("Exiting retirer with documents:", documents)
 }
 
+func (l LogHandler) HandleRetrieverError(_ context.Context, err error) {
+	fmt.Println("Exiting retriever with error:", err)
+}
+
 func formatChainValues(values map[string]any) string {
 	output := ""
 	for key, value := range values {
@@ -0,0 +1,10 @@
+// Package opentelemetry provides a callbacks handler that traces LLM calls,
+// chains, tools and retrievers as OpenTelemetry spans.
+//
+// Spans nest as their runs do: the LLM calls of a chain are children of the
+// span of the chain, and runs outside any traced run are children of the span
+// of their context, if any. LLM spans carry the model and token usage reported
+// by the provider, and the number and length of the prompts. Tool spans carry
+// the name of the tool. Failed runs record the error and set the error status.
+// Agent actions are recorded as events of the span of the agent's chain.
+package opentelemetry
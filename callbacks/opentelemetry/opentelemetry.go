@@ -0,0 +1,255 @@
+package opentelemetry
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// _instrumentationName is the name of the tracer of the handler.
+const _instrumentationName = "github.com/aresa7796/langchaingo/callbacks/opentelemetry"
+
+// Names of the spans of the handler.
+const (
+	SpanChain     = "chain"
+	SpanLLM       = "llm"
+	SpanTool      = "tool"
+	SpanRetriever = "retriever"
+)
+
+// Names of the span events of the handler.
+const (
+	EventAgentAction = "agent_action"
+	EventText        = "text"
+	EventFirstToken  = "first_token"
+)
+
+// Attribute keys of the spans and events of the handler.
+const (
+	ModelKey            = attribute.Key("gen_ai.response.model")
+	PromptTokensKey     = attribute.Key("gen_ai.usage.prompt_tokens")
+	CompletionTokensKey = attribute.Key("gen_ai.usage.completion_tokens")
+	TotalTokensKey      = attribute.Key("gen_ai.usage.total_tokens")
+	PromptCountKey      = attribute.Key("llm.prompt.count")
+	PromptLengthKey     = attribute.Key("llm.prompt.length")
+	CacheHitKey         = attribute.Key("llm.cache_hit")
+	ToolNameKey         = attribute.Key("tool.name")
+	ToolInputKey        = attribute.Key("tool.input")
+	DocumentCountKey    = attribute.Key("retriever.documents.count")
+	ChainInputKeysKey   = attribute.Key("chain.input.keys")
+	ChainOutputKeysKey  = attribute.Key("chain.output.keys")
+	TextKey             = attribute.Key("text")
+)
+
+// span is a span started for a run.
+type span struct {
+	span       trace.Span
+	firstToken bool
+}
+
+// Handler is a callbacks handler that traces runs as OpenTelemetry spans. Each
+// LLM call, chain, tool and retriever run is a span, nested in the span of the
+// run it belongs to, or in the span of the context if it belongs to no traced
+// run. Agent actions and texts are events of the span of their run.
+type Handler struct {
+	tracer trace.Tracer
+
+	mu    sync.Mutex
+	spans map[string]*span
+}
+
+var _ callbacks.Handler = (*Handler)(nil)
+
+// NewHandler creates a tracing handler. It uses the global tracer provider
+// unless another tracer provider or tracer is given.
+func NewHandler(opts ...Option) *Handler {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	tracer := o.tracer
+	if tracer == nil {
+		provider := o.tracerProvider
+		if provider == nil {
+			provider = otel.GetTracerProvider()
+		}
+		tracer = provider.Tracer(_instrumentationName)
+	}
+
+	return &Handler{tracer: tracer, spans: make(map[string]*span)}
+}
+
+func (h *Handler) HandleText(ctx context.Context, text string) {
+	h.addEvent(ctx, EventText, TextKey.String(text))
+}
+
+func (h *Handler) HandleLLMStart(ctx context.Context, prompts []string) {
+	length := 0
+	for _, prompt := range prompts {
+		length += len(prompt)
+	}
+	h.start(ctx, SpanLLM,
+		PromptCountKey.Int(len(prompts)),
+		PromptLengthKey.Int(length),
+	)
+}
+
+func (h *Handler) HandleLLMNewToken(ctx context.Context, _ string) {
+	h.mu.Lock()
+	s, ok := h.spans[callbacks.RunID(ctx)]
+	first := ok && !s.firstToken
+	if first {
+		s.firstToken = true
+	}
+	h.mu.Unlock()
+
+	if first {
+		s.span.AddEvent(EventFirstToken)
+	}
+}
+
+func (h *Handler) HandleLLMEnd(ctx context.Context, output llms.LLMResult) {
+	attrs := []attribute.KeyValue{}
+	if usage := output.Usage; usage != nil {
+		if usage.Model != "" {
+			attrs = append(attrs, ModelKey.String(usage.Model))
+		}
+		attrs = append(attrs,
+			PromptTokensKey.Int(usage.PromptTokens),
+			CompletionTokensKey.Int(usage.CompletionTokens),
+			TotalTokensKey.Int(usage.TotalTokens),
+		)
+	}
+	if hit, ok := output.LLMOutput["cache_hit"].(bool); ok {
+		attrs = append(attrs, CacheHitKey.Bool(hit))
+	}
+	h.end(ctx, attrs...)
+}
+
+func (h *Handler) HandleLLMError(ctx context.Context, err error) {
+	h.fail(ctx, err)
+}
+
+func (h *Handler) HandleChainStart(ctx context.Context, inputs map[string]any) {
+	h.start(ctx, SpanChain, ChainInputKeysKey.StringSlice(keys(inputs)))
+}
+
+func (h *Handler) HandleChainEnd(ctx context.Context, outputs map[string]any) {
+	h.end(ctx, ChainOutputKeysKey.StringSlice(keys(outputs)))
+}
+
+func (h *Handler) HandleChainError(ctx context.Context, err error) {
+	h.fail(ctx, err)
+}
+
+func (h *Handler) HandleToolStart(ctx context.Context, input string) {
+	attrs := []attribute.KeyValue{ToolInputKey.String(input)}
+	if name := callbacks.RunName(ctx); name != "" {
+		attrs = append(attrs, ToolNameKey.String(name))
+	}
+	h.start(ctx, SpanTool, attrs...)
+}
+
+func (h *Handler) HandleToolEnd(ctx context.Context, _ string) {
+	h.end(ctx)
+}
+
+func (h *Handler) HandleToolError(ctx context.Context, err error) {
+	h.fail(ctx, err)
+}
+
+func (h *Handler) HandleAgentAction(ctx context.Context, action schema.AgentAction) {
+	h.addEvent(ctx, EventAgentAction,
+		ToolNameKey.String(action.Tool),
+		ToolInputKey.String(action.ToolInput),
+	)
+}
+
+func (h *Handler) HandleRetrieverStart(ctx context.Context, _ string) {
+	h.start(ctx, SpanRetriever)
+}
+
+func (h *Handler) HandleRetrieverEnd(ctx context.Context, documents []schema.Document) {
+	h.end(ctx, DocumentCountKey.Int(len(documents)))
+}
+
+func (h *Handler) HandleRetrieverError(ctx context.Context, err error) {
+	h.fail(ctx, err)
+}
+
+// start starts the span of the run of the context, as a child of the span of
+// its parent run if it is traced, or of the span of the context otherwise.
+func (h *Handler) start(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if parent, ok := h.spans[callbacks.ParentRunID(ctx)]; ok {
+		ctx = trace.ContextWithSpan(ctx, parent.span)
+	}
+	_, s := h.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	h.spans[callbacks.RunID(ctx)] = &span{span: s}
+}
+
+// end ends the span of the run of the context.
+func (h *Handler) end(ctx context.Context, attrs ...attribute.KeyValue) {
+	s, ok := h.pop(ctx)
+	if !ok {
+		return
+	}
+	s.span.SetAttributes(attrs...)
+	s.span.End()
+}
+
+// fail ends the span of the run of the context with the error.
+func (h *Handler) fail(ctx context.Context, err error) {
+	s, ok := h.pop(ctx)
+	if !ok {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+	s.span.End()
+}
+
+// pop removes the span of the run of the context.
+func (h *Handler) pop(ctx context.Context) (*span, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := callbacks.RunID(ctx)
+	s, ok := h.spans[id]
+	delete(h.spans, id)
+	return s, ok
+}
+
+// addEvent adds the event to the span of the run of the context, or to the
+// span of the context if the run is not traced.
+func (h *Handler) addEvent(ctx context.Context, name string, attrs ...attribute.KeyValue) {
+	h.mu.Lock()
+	s, ok := h.spans[callbacks.RunID(ctx)]
+	h.mu.Unlock()
+
+	target := trace.SpanFromContext(ctx)
+	if ok {
+		target = s.span
+	}
+	target.AddEvent(name, trace.WithAttributes(attrs...))
+}
+
+func keys(values map[string]any) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package opentelemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/fake"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/tools"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestHandler() (*Handler, *tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	return NewHandler(WithTracerProvider(provider)), exporter, provider
+}
+
+func spanNamed(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, s := range spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q", name)
+	return tracetest.SpanStub{}
+}
+
+func attributes(s tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(s.Attributes))
+	for _, kv := range s.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestHandlerNestsLLMInChain(t *testing.T) {
+	t.Parallel()
+
+	handler, exporter, _ := newTestHandler()
+	llm := fake.NewLLM(fake.WithResponses(fake.Response{
+		Text:  "hello",
+		Usage: llms.NewUsage("fake-model", 3, 2),
+	}))
+	c := chains.NewLLMChain(llm, prompts.NewPromptTemplate("say hi", nil))
+
+	ctx := callbacks.WithHandlers(context.Background(), handler)
+	_, err := chains.Call(ctx, c, map[string]any{})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	chain := spanNamed(t, spans, SpanChain)
+	llmSpan := spanNamed(t, spans, SpanLLM)
+
+	require.Equal(t, chain.SpanContext.TraceID(), llmSpan.SpanContext.TraceID())
+	require.Equal(t, chain.SpanContext.SpanID(), llmSpan.Parent.SpanID())
+	require.False(t, chain.Parent.IsValid())
+
+	attrs := attributes(llmSpan)
+	require.Equal(t, "fake-model", attrs[ModelKey].AsString())
+	require.Equal(t, int64(3), attrs[PromptTokensKey].AsInt64())
+	require.Equal(t, int64(2), attrs[CompletionTokensKey].AsInt64())
+	require.Equal(t, int64(5), attrs[TotalTokensKey].AsInt64())
+	require.Equal(t, int64(1), attrs[PromptCountKey].AsInt64())
+	require.Equal(t, int64(len("say hi")), attrs[PromptLengthKey].AsInt64())
+	require.Equal(t, codes.Unset, llmSpan.Status.Code)
+}
+
+func TestHandlerNestsInContextSpan(t *testing.T) {
+	t.Parallel()
+
+	handler, exporter, provider := newTestHandler()
+	llm := fake.NewLLM(fake.WithTexts("hello"))
+	llm.CallbacksHandler = handler
+
+	ctx, outer := provider.Tracer("test").Start(context.Background(), "outer")
+	_, err := llm.Call(ctx, "hi")
+	require.NoError(t, err)
+	outer.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	require.Equal(t, outer.SpanContext().SpanID(), spanNamed(t, spans, SpanLLM).Parent.SpanID())
+}
+
+func TestHandlerRecordsErrors(t *testing.T) {
+	t.Parallel()
+
+	handler, exporter, _ := newTestHandler()
+	errModel := errors.New("model unavailable")
+	llm := fake.NewLLM(fake.WithResponses(fake.Response{Err: errModel}))
+	llm.CallbacksHandler = handler
+
+	_, err := llm.Call(context.Background(), "hi")
+	require.ErrorIs(t, err, errModel)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status.Code)
+	require.Equal(t, errModel.Error(), spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1)
+	require.Equal(t, "exception", spans[0].Events[0].Name)
+}
+
+func TestHandlerTools(t *testing.T) {
+	t.Parallel()
+
+	handler, exporter, _ := newTestHandler()
+	calculator := tools.Calculator{CallbacksHandler: handler}
+
+	_, err := calculator.Call(context.Background(), "1 + 1")
+	require.NoError(t, err)
+	_, err = calculator.Call(context.Background(), "1 +")
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	for _, s := range spans {
+		require.Equal(t, SpanTool, s.Name)
+		require.Equal(t, "calculator", attributes(s)[ToolNameKey].AsString())
+	}
+	// Evaluator errors are observations of successful runs.
+	require.Equal(t, codes.Unset, spans[0].Status.Code)
+	require.Equal(t, codes.Unset, spans[1].Status.Code)
+}
+
+func TestHandlerAgentActionEvents(t *testing.T) {
+	t.Parallel()
+
+	handler, exporter, _ := newTestHandler()
+	ctx := callbacks.StartRun(context.Background())
+	handler.HandleChainStart(ctx, map[string]any{"input": "2 + 2"})
+	handler.HandleAgentAction(ctx, schema.AgentAction{Tool: "calculator", ToolInput: "2 + 2"})
+	handler.HandleChainEnd(ctx, map[string]any{"output": "4"})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].Events, 1)
+	event := spans[0].Events[0]
+	require.Equal(t, EventAgentAction, event.Name)
+	require.Contains(t, event.Attributes, ToolNameKey.String("calculator"))
+	require.Equal(t, []string{"input"}, attributes(spans[0])[ChainInputKeysKey].AsStringSlice())
+}
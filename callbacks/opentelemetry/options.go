@@ -0,0 +1,27 @@
+package opentelemetry
+
+import "go.opentelemetry.io/otel/trace"
+
+type options struct {
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+}
+
+// Option is an option of the tracing handler.
+type Option func(*options)
+
+// WithTracerProvider sets the tracer provider the tracer of the handler is
+// created with.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithTracer sets the tracer of the handler. It takes precedence over
+// WithTracerProvider.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
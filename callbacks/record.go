@@ -0,0 +1,237 @@
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"golang.org/x/exp/slog"
+)
+
+// Events of the records of a RecordHandler.
+const (
+	EventText           = "text"
+	EventLLMStart       = "llm_start"
+	EventLLMNewToken    = "llm_new_token"
+	EventLLMEnd         = "llm_end"
+	EventLLMError       = "llm_error"
+	EventChainStart     = "chain_start"
+	EventChainEnd       = "chain_end"
+	EventChainError     = "chain_error"
+	EventToolStart      = "tool_start"
+	EventToolEnd        = "tool_end"
+	EventToolError      = "tool_error"
+	EventAgentAction    = "agent_action"
+	EventRetrieverStart = "retriever_start"
+	EventRetrieverEnd   = "retriever_end"
+	EventRetrieverError = "retriever_error"
+)
+
+// Record is a callbacks event as written by a RecordHandler. Only the fields of
+// the event are set.
+type Record struct {
+	Time        time.Time `json:"time"`
+	Event       string    `json:"event"`
+	RunID       string    `json:"run_id,omitempty"`
+	ParentRunID string    `json:"parent_run_id,omitempty"`
+	RunName     string    `json:"run_name,omitempty"`
+
+	// Text is the text of text events and the token of new token events.
+	Text      string              `json:"text,omitempty"`
+	Prompts   []string            `json:"prompts,omitempty"`
+	Result    *llms.LLMResult     `json:"result,omitempty"`
+	Inputs    map[string]any      `json:"inputs,omitempty"`
+	Outputs   map[string]any      `json:"outputs,omitempty"`
+	Input     string              `json:"input,omitempty"`
+	Output    string              `json:"output,omitempty"`
+	Action    *schema.AgentAction `json:"action,omitempty"`
+	Query     string              `json:"query,omitempty"`
+	Documents []schema.Document   `json:"documents,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// RecordHandler is a callbacks handler that writes every event as a Record,
+// with the time of the event and the ids of its run. Records are written as
+// JSON lines with NewJSONLinesHandler, or logged with NewSlogHandler. Both can
+// be read back with ReadRecords to replay the run tree.
+//
+// Chain inputs and outputs that cannot be encoded as JSON are recorded as
+// their fmt.Sprint text.
+type RecordHandler struct {
+	write func(ctx context.Context, r Record) error
+	now   func() time.Time
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ Handler = (*RecordHandler)(nil)
+
+// NewJSONLinesHandler returns a handler that writes records to w, one JSON
+// object per line.
+func NewJSONLinesHandler(w io.Writer) *RecordHandler {
+	enc := json.NewEncoder(w)
+	return &RecordHandler{
+		write: func(_ context.Context, r Record) error {
+			return enc.Encode(r)
+		},
+		now: time.Now,
+	}
+}
+
+// NewSlogHandler returns a handler that logs records to the logger at the info
+// level, and at the error level for errors. Logged with a slog.JSONHandler,
+// the lines can be read back with ReadRecords.
+func NewSlogHandler(logger *slog.Logger) *RecordHandler {
+	return &RecordHandler{
+		write: func(ctx context.Context, r Record) error {
+			level := slog.LevelInfo
+			if r.Error != "" {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "langchaingo "+r.Event, recordAttrs(r)...)
+			return nil
+		},
+		now: time.Now,
+	}
+}
+
+// Err returns the first error writing a record, if any. The handler stops
+// writing records after an error.
+func (h *RecordHandler) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.err
+}
+
+func (h *RecordHandler) HandleText(ctx context.Context, text string) {
+	h.record(ctx, Record{Event: EventText, Text: text})
+}
+
+func (h *RecordHandler) HandleLLMStart(ctx context.Context, prompts []string) {
+	h.record(ctx, Record{Event: EventLLMStart, Prompts: prompts})
+}
+
+func (h *RecordHandler) HandleLLMNewToken(ctx context.Context, token string) {
+	h.record(ctx, Record{Event: EventLLMNewToken, Text: token})
+}
+
+func (h *RecordHandler) HandleLLMEnd(ctx context.Context, output llms.LLMResult) {
+	h.record(ctx, Record{Event: EventLLMEnd, Result: &output})
+}
+
+func (h *RecordHandler) HandleLLMError(ctx context.Context, err error) {
+	h.record(ctx, Record{Event: EventLLMError, Error: err.Error()})
+}
+
+func (h *RecordHandler) HandleChainStart(ctx context.Context, inputs map[string]any) {
+	h.record(ctx, Record{Event: EventChainStart, Inputs: encodableValues(inputs)})
+}
+
+func (h *RecordHandler) HandleChainEnd(ctx context.Context, outputs map[string]any) {
+	h.record(ctx, Record{Event: EventChainEnd, Outputs: encodableValues(outputs)})
+}
+
+func (h *RecordHandler) HandleChainError(ctx context.Context, err error) {
+	h.record(ctx, Record{Event: EventChainError, Error: err.Error()})
+}
+
+func (h *RecordHandler) HandleToolStart(ctx context.Context, input string) {
+	h.record(ctx, Record{Event: EventToolStart, Input: input})
+}
+
+func (h *RecordHandler) HandleToolEnd(ctx context.Context, output string) {
+	h.record(ctx, Record{Event: EventToolEnd, Output: output})
+}
+
+func (h *RecordHandler) HandleToolError(ctx context.Context, err error) {
+	h.record(ctx, Record{Event: EventToolError, Error: err.Error()})
+}
+
+func (h *RecordHandler) HandleAgentAction(ctx context.Context, action schema.AgentAction) {
+	h.record(ctx, Record{Event: EventAgentAction, Action: &action})
+}
+
+func (h *RecordHandler) HandleRetrieverStart(ctx context.Context, query string) {
+	h.record(ctx, Record{Event: EventRetrieverStart, Query: query})
+}
+
+func (h *RecordHandler) HandleRetrieverEnd(ctx context.Context, documents []schema.Document) {
+	h.record(ctx, Record{Event: EventRetrieverEnd, Documents: documents})
+}
+
+func (h *RecordHandler) HandleRetrieverError(ctx context.Context, err error) {
+	h.record(ctx, Record{Event: EventRetrieverError, Error: err.Error()})
+}
+
+// record completes the record with the time and run of the event and writes it.
+func (h *RecordHandler) record(ctx context.Context, r Record) {
+	r.Time = h.now()
+	r.RunID = RunID(ctx)
+	r.ParentRunID = ParentRunID(ctx)
+	r.RunName = RunName(ctx)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err != nil {
+		return
+	}
+	h.err = h.write(ctx, r)
+}
+
+// recordAttrs returns the set fields of the record as log attributes, keyed
+// as in its JSON encoding. The time is left to the logger.
+func recordAttrs(r Record) []slog.Attr {
+	attrs := []slog.Attr{slog.String("event", r.Event)}
+	addString := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	addString("run_id", r.RunID)
+	addString("parent_run_id", r.ParentRunID)
+	addString("run_name", r.RunName)
+	addString("text", r.Text)
+	addString("input", r.Input)
+	addString("output", r.Output)
+	addString("query", r.Query)
+	addString("error", r.Error)
+
+	addAny := func(key string, value any, set bool) {
+		if set {
+			attrs = append(attrs, slog.Any(key, value))
+		}
+	}
+	addAny("prompts", r.Prompts, r.Prompts != nil)
+	addAny("result", r.Result, r.Result != nil)
+	addAny("inputs", r.Inputs, r.Inputs != nil)
+	addAny("outputs", r.Outputs, r.Outputs != nil)
+	addAny("action", r.Action, r.Action != nil)
+	addAny("documents", r.Documents, r.Documents != nil)
+	return attrs
+}
+
+// encodableValues returns the values with the ones that cannot be encoded as
+// JSON replaced by their text.
+func encodableValues(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+
+	encodable := make(map[string]any, len(values))
+	for key, value := range values {
+		if _, err := json.Marshal(value); err != nil {
+			encodable[key] = fmt.Sprint(value)
+			continue
+		}
+		encodable[key] = value
+	}
+	return encodable
+}
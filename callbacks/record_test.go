@@ -0,0 +1,126 @@
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// recordAgentRun reports the events of an agent chain that calls an llm and a
+// failing tool to the handler.
+func recordAgentRun(h Handler) {
+	chainCtx := StartRun(context.Background())
+	h.HandleChainStart(chainCtx, map[string]any{"input": "2 + 2", "callback": func() {}})
+
+	llmCtx := StartRun(chainCtx)
+	h.HandleLLMStart(llmCtx, []string{"what is 2 + 2?"})
+	h.HandleLLMNewToken(llmCtx, "calc")
+	h.HandleLLMEnd(llmCtx, llms.NewLLMResult([]*llms.Generation{{
+		Text:  "calculator",
+		Usage: llms.NewUsage("test-model", 4, 1),
+	}}))
+
+	h.HandleAgentAction(chainCtx, schema.AgentAction{Tool: "calculator", ToolInput: "2 +"})
+	toolCtx := StartNamedRun(chainCtx, "calculator")
+	h.HandleToolStart(toolCtx, "2 +")
+	h.HandleToolError(toolCtx, errors.New("syntax error"))
+
+	h.HandleChainError(chainCtx, errors.New("agent failed"))
+}
+
+func TestJSONLinesHandlerReplay(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	h := NewJSONLinesHandler(&buf)
+	tick := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	h.now = func() time.Time {
+		tick = tick.Add(time.Second)
+		return tick
+	}
+	recordAgentRun(h)
+	require.NoError(t, h.Err())
+
+	records, err := ReadRecords(strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	require.Len(t, records, 8)
+	require.Equal(t, EventChainStart, records[0].Event)
+	require.Equal(t, "2 + 2", records[0].Inputs["input"])
+	require.IsType(t, "", records[0].Inputs["callback"])
+	require.Equal(t, "test-model", records[3].Result.Usage.Model)
+
+	runs := BuildRunTree(records)
+	require.Len(t, runs, 1)
+	chain := runs[0]
+	require.Equal(t, "chain", chain.Kind)
+	require.Equal(t, "agent failed", chain.Error)
+	require.Equal(t, 7*time.Second, chain.Duration())
+	require.Len(t, chain.Children, 2)
+	require.Equal(t, "llm", chain.Children[0].Kind)
+	require.Empty(t, chain.Children[0].Error)
+	require.Equal(t, "tool", chain.Children[1].Kind)
+	require.Equal(t, "calculator", chain.Children[1].Name)
+	require.Equal(t, "syntax error", chain.Children[1].Error)
+
+	var tree strings.Builder
+	require.NoError(t, WriteRunTree(&tree, runs))
+	lines := strings.Split(strings.TrimSpace(tree.String()), "\n")
+	require.Len(t, lines, 6)
+	require.True(t, strings.HasPrefix(lines[0], "chain "+chain.ID))
+	require.Equal(t, "  action calculator: 2 +", lines[1])
+	require.Equal(t, "  error: agent failed", lines[2])
+	require.True(t, strings.HasPrefix(lines[3], "  llm "))
+	require.True(t, strings.HasPrefix(lines[4], "  tool calculator "))
+	require.Equal(t, "    error: syntax error", lines[5])
+}
+
+func TestSlogHandlerReplay(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("unrelated line")
+	recordAgentRun(NewSlogHandler(logger))
+
+	require.Contains(t, buf.String(), `"level":"ERROR"`)
+
+	runs, err := Replay(&buf)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, "agent failed", runs[0].Error)
+	require.Len(t, runs[0].Children, 2)
+	require.Equal(t, "calculator", runs[0].Children[1].Name)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecordHandlerErr(t *testing.T) {
+	t.Parallel()
+
+	h := NewJSONLinesHandler(failingWriter{})
+	h.HandleText(context.Background(), "hello")
+	require.EqualError(t, h.Err(), "disk full")
+}
+
+func TestReadRecordsOtherLines(t *testing.T) {
+	t.Parallel()
+
+	records, err := ReadRecords(strings.NewReader("{\"event\":\"text\"}\nnot json\n{\"msg\":\"other\",\"time\":1}\n"))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	_, err = ReadRecords(strings.NewReader("not json\n{\"event\":1}\n"))
+	require.ErrorContains(t, err, "line 2")
+}
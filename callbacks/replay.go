@@ -0,0 +1,176 @@
+package callbacks
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// _maxRecordSize is the size of the longest line ReadRecords reads.
+const _maxRecordSize = 16 << 20
+
+// ReadRecords reads the records written by a RecordHandler, either as JSON
+// lines or logged with a slog.JSONHandler. Lines that are not records, such as
+// other log lines or text that is not JSON, are skipped. Lines with an event
+// that do not decode as a record are an error.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, _maxRecordSize)
+
+	records := []Record{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || !json.Valid([]byte(text)) {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			if !hasEvent(text) {
+				continue
+			}
+			return nil, fmt.Errorf("reading record on line %d: %w", line, err)
+		}
+		if record.Event == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+
+	return records, nil
+}
+
+// hasEvent reports whether the JSON line has an event, and so is meant to be a
+// record.
+func hasEvent(text string) bool {
+	var line struct {
+		Event json.RawMessage `json:"event"`
+	}
+	return json.Unmarshal([]byte(text), &line) == nil && len(line.Event) > 0
+}
+
+// Run is a run replayed from records.
+type Run struct {
+	ID       string
+	ParentID string
+	Name     string
+	// Kind is the kind of run, "llm", "chain", "tool" or "retriever", or empty
+	// if its start was not recorded.
+	Kind  string
+	Start time.Time
+	End   time.Time
+	// Error is the error the run failed with, if any.
+	Error string
+	// Records are the records of the run, without those of its children.
+	Records  []Record
+	Children []*Run
+}
+
+// Duration returns the time between the first and last record of the run.
+func (r *Run) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// BuildRunTree reconstructs the runs of the records, nested as they ran. The
+// runs whose parent was not recorded are returned as roots, in the order they
+// started. Records without a run id are gathered in a root run with no id.
+func BuildRunTree(records []Record) []*Run {
+	runs := map[string]*Run{}
+	order := []*Run{}
+	for _, record := range records {
+		run, ok := runs[record.RunID]
+		if !ok {
+			run = &Run{ID: record.RunID, ParentID: record.ParentRunID, Start: record.Time}
+			runs[record.RunID] = run
+			order = append(order, run)
+		}
+
+		if record.RunName != "" {
+			run.Name = record.RunName
+		}
+		if kind, event, ok := strings.Cut(record.Event, "_"); ok {
+			switch event {
+			case "start":
+				run.Kind = kind
+			case "error":
+				run.Error = record.Error
+			}
+		}
+		run.End = record.Time
+		run.Records = append(run.Records, record)
+	}
+
+	roots := []*Run{}
+	for _, run := range order {
+		parent, ok := runs[run.ParentID]
+		if run.ID == "" || run.ParentID == "" || !ok {
+			roots = append(roots, run)
+			continue
+		}
+		parent.Children = append(parent.Children, run)
+	}
+	return roots
+}
+
+// Replay reads the records of r and reconstructs their runs.
+func Replay(r io.Reader) ([]*Run, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return BuildRunTree(records), nil
+}
+
+// WriteRunTree writes the runs as an indented tree, one line per run followed
+// by the agent actions and error of the run.
+func WriteRunTree(w io.Writer, runs []*Run) error {
+	for _, run := range runs {
+		if err := writeRun(w, run, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRun(w io.Writer, run *Run, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	title := run.Kind
+	if title == "" {
+		title = "run"
+	}
+	if run.Name != "" {
+		title += " " + run.Name
+	}
+	if _, err := fmt.Fprintf(w, "%s%s %s (%s)\n", indent, title, run.ID, run.Duration()); err != nil {
+		return err
+	}
+
+	for _, record := range run.Records {
+		if record.Event != EventAgentAction || record.Action == nil {
+			continue
+		}
+		_, err := fmt.Fprintf(w, "%s  action %s: %s\n", indent, record.Action.Tool, record.Action.ToolInput)
+		if err != nil {
+			return err
+		}
+	}
+	if run.Error != "" {
+		if _, err := fmt.Fprintf(w, "%s  error: %s\n", indent, run.Error); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range run.Children {
+		if err := writeRun(w, child, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// runKey is the context key of the current run.
+type runKey struct{}
+
+// run identifies a run of an LLM, chain, tool or retriever, and the run it is
+// nested in.
+type run struct {
+	id       string
+	parentID string
+	name     string
+}
+
+// StartRun returns a context for a new run nested in the run of ctx, if any.
+// Components call it before reporting their start event and report all the
+// events of the run, and call their children, with the returned context. This
+// is how the LLM calls of a chain are attributed to the chain.
+func StartRun(ctx context.Context) context.Context {
+	return StartNamedRun(ctx, "")
+}
+
+// StartNamedRun is like StartRun, and names the run, for instance after the
+// tool that runs.
+func StartNamedRun(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, runKey{}, run{
+		id:       uuid.NewString(),
+		parentID: RunID(ctx),
+		name:     name,
+	})
+}
+
+// RunID returns the id of the run of the context, or an empty string outside of
+// runs.
+func RunID(ctx context.Context) string {
+	r, _ := ctx.Value(runKey{}).(run)
+	return r.id
+}
+
+// ParentRunID returns the id of the run the run of the context is nested in, or
+// an empty string for top-level runs.
+func ParentRunID(ctx context.Context) string {
+	r, _ := ctx.Value(runKey{}).(run)
+	return r.parentID
+}
+
+// RunName returns the name of the run of the context, or an empty string if it
+// has none.
+func RunName(ctx context.Context) string {
+	r, _ := ctx.Value(runKey{}).(run)
+	return r.name
+}
@@ -0,0 +1,56 @@
+package callbacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	require.Empty(t, RunID(ctx))
+
+	chainCtx := StartRun(ctx)
+	require.NotEmpty(t, RunID(chainCtx))
+	require.Empty(t, ParentRunID(chainCtx))
+
+	llmCtx := StartRun(chainCtx)
+	require.NotEqual(t, RunID(chainCtx), RunID(llmCtx))
+	require.Equal(t, RunID(chainCtx), ParentRunID(llmCtx))
+	require.Empty(t, RunName(llmCtx))
+
+	toolCtx := StartNamedRun(chainCtx, "calculator")
+	require.Equal(t, "calculator", RunName(toolCtx))
+	require.Equal(t, RunID(chainCtx), ParentRunID(toolCtx))
+}
+
+// tokenRecorder records the new tokens given to the handler.
+type tokenRecorder struct {
+	SimpleHandler
+	tokens []string
+}
+
+func (r *tokenRecorder) HandleLLMNewToken(_ context.Context, token string) {
+	r.tokens = append(r.tokens, token)
+}
+
+func TestStreamingFunc(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, StreamingFunc(&tokenRecorder{}, nil))
+
+	var chunks []string
+	next := func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}
+	recorder := &tokenRecorder{}
+	f := StreamingFunc(recorder, next)
+	require.NoError(t, f(context.Background(), []byte("Hello")))
+	require.NoError(t, f(context.Background(), []byte(" world")))
+	require.Equal(t, []string{"Hello", " world"}, recorder.tokens)
+	require.Equal(t, recorder.tokens, chunks)
+}
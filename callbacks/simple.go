@@ -0,0 +1,30 @@
+package callbacks
+
+import (
+	"context"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// SimpleHandler is a callback handler that does nothing. Embed it in a handler
+// to only implement the methods it needs.
+type SimpleHandler struct{}
+
+var _ Handler = SimpleHandler{}
+
+func (SimpleHandler) HandleText(context.Context, string)                    {}
+func (SimpleHandler) HandleLLMStart(context.Context, []string)              {}
+func (SimpleHandler) HandleLLMNewToken(context.Context, string)             {}
+func (SimpleHandler) HandleLLMEnd(context.Context, llms.LLMResult)          {}
+func (SimpleHandler) HandleLLMError(context.Context, error)                 {}
+func (SimpleHandler) HandleChainStart(context.Context, map[string]any)      {}
+func (SimpleHandler) HandleChainEnd(context.Context, map[string]any)        {}
+func (SimpleHandler) HandleChainError(context.Context, error)               {}
+func (SimpleHandler) HandleToolStart(context.Context, string)               {}
+func (SimpleHandler) HandleToolEnd(context.Context, string)                 {}
+func (SimpleHandler) HandleToolError(context.Context, error)                {}
+func (SimpleHandler) HandleAgentAction(context.Context, schema.AgentAction) {}
+func (SimpleHandler) HandleRetrieverStart(context.Context, string)          {}
+func (SimpleHandler) HandleRetrieverEnd(context.Context, []schema.Document) {}
+func (SimpleHandler) HandleRetrieverError(context.Context, error)           {}
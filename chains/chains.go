@@ -42,20 +42,17 @@ func Call(ctx context.Context, c Chain, inputValues map[string]any, options ...C
 		fullValues[key] = value
 	}
 
-	callbacksHandler := getChainCallbackHandler(c)
+	ctx = callbacks.StartRun(ctx)
+	callbacksHandler := callbacks.HandlerFor(ctx, getChainCallbackHandler(c))
 	if callbacksHandler != nil {
 		callbacksHandler.HandleChainStart(ctx, inputValues)
 	}
 
-	if err := validateInputs(c, fullValues); err != nil {
-		return nil, err
-	}
-
-	outputValues, err := c.Call(ctx, fullValues, options...)
+	outputValues, err := callChain(ctx, c, fullValues, options...)
 	if err != nil {
-		return nil, err
-	}
-	if err := validateOutputs(c, outputValues); err != nil {
+		if callbacksHandler != nil {
+			callbacksHandler.HandleChainError(ctx, err)
+		}
 		return nil, err
 	}
 
@@ -71,6 +68,23 @@ func Call(ctx context.Context, c Chain, inputValues map[string]any, options ...C
 	return outputValues, nil
 }
 
+// callChain validates the inputs, calls the chain and validates its outputs.
+func callChain(ctx context.Context, c Chain, fullValues map[string]any, options ...ChainCallOption) (map[string]any, error) { // nolint: lll
+	if err := validateInputs(c, fullValues); err != nil {
+		return nil, err
+	}
+
+	outputValues, err := c.Call(ctx, fullValues, options...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOutputs(c, outputValues); err != nil {
+		return nil, err
+	}
+
+	return outputValues, nil
+}
+
 // Run can be used to execute a chain if the chain only expects one input and one
 // string output.
 func Run(ctx context.Context, c Chain, input any, options ...ChainCallOption) (string, error) {
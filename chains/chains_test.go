@@ -2,12 +2,15 @@ package chains
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/fake"
 	"github.com/aresa7796/langchaingo/prompts"
 	"github.com/aresa7796/langchaingo/schema"
 	"github.com/stretchr/testify/require"
@@ -85,3 +88,104 @@ func TestApplyWithCanceledContext(t *testing.T) {
 	cancelFunc()
 	wg.Wait()
 }
+
+// runRecorder records the run ids and errors given to the callbacks handler.
+type runRecorder struct {
+	callbacks.SimpleHandler
+	chainRuns []string
+	errs      []error
+}
+
+func (r *runRecorder) HandleChainStart(ctx context.Context, _ map[string]any) {
+	r.chainRuns = append(r.chainRuns, callbacks.RunID(ctx))
+}
+
+func (r *runRecorder) HandleChainError(_ context.Context, err error) {
+	r.errs = append(r.errs, err)
+}
+
+// runModel is a language model that records the run of its context.
+type runModel struct {
+	err  error
+	runs []string
+}
+
+func (m *runModel) GeneratePrompt(ctx context.Context, _ []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	m.runs = append(m.runs, callbacks.RunID(ctx))
+	if m.err != nil {
+		return llms.LLMResult{}, m.err
+	}
+	return llms.NewLLMResult([]*llms.Generation{{Text: "ok"}}), nil
+}
+
+func (m *runModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+func TestCallCallbacks(t *testing.T) {
+	t.Parallel()
+
+	recorder := &runRecorder{}
+	model := &runModel{}
+	c := NewLLMChain(model, prompts.NewPromptTemplate("test", nil))
+	c.CallbacksHandler = recorder
+
+	_, err := Call(context.Background(), c, map[string]any{})
+	require.NoError(t, err)
+	require.Len(t, recorder.chainRuns, 1)
+	require.NotEmpty(t, recorder.chainRuns[0])
+	// The model is called within the run of the chain.
+	require.Equal(t, recorder.chainRuns, model.runs)
+	require.Empty(t, recorder.errs)
+
+	model.err = errors.New("model failed")
+	_, err = Call(context.Background(), c, map[string]any{})
+	require.ErrorIs(t, err, model.err)
+	require.Equal(t, []error{model.err}, recorder.errs)
+	require.NotEqual(t, recorder.chainRuns[0], recorder.chainRuns[1])
+}
+
+func TestCallContextHandlers(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+	recorder := &contextRecorder{events: &events}
+	llm := fake.NewLLM(fake.WithTexts("ok"))
+	c := NewLLMChain(llm, prompts.NewPromptTemplate("test", nil))
+
+	ctx := callbacks.WithHandlers(context.Background(), recorder)
+	_, err := Call(ctx, c, map[string]any{})
+	require.NoError(t, err)
+
+	// The chain and its LLM report to the handler of the context, without it
+	// being set on either, and the LLM call is nested in the chain run.
+	require.Equal(t, []string{"chain start", "llm start", "llm end", "chain end"}, events)
+	require.Equal(t, recorder.chainRun, recorder.llmParentRun)
+	require.NotEmpty(t, recorder.chainRun)
+}
+
+// contextRecorder records the chain and llm events it gets.
+type contextRecorder struct {
+	callbacks.SimpleHandler
+	events       *[]string
+	chainRun     string
+	llmParentRun string
+}
+
+func (r *contextRecorder) HandleChainStart(ctx context.Context, _ map[string]any) {
+	*r.events = append(*r.events, "chain start")
+	r.chainRun = callbacks.RunID(ctx)
+}
+
+func (r *contextRecorder) HandleChainEnd(context.Context, map[string]any) {
+	*r.events = append(*r.events, "chain end")
+}
+
+func (r *contextRecorder) HandleLLMStart(ctx context.Context, _ []string) {
+	*r.events = append(*r.events, "llm start")
+	r.llmParentRun = callbacks.ParentRunID(ctx)
+}
+
+func (r *contextRecorder) HandleLLMEnd(context.Context, llms.LLMResult) {
+	*r.events = append(*r.events, "llm end")
+}
@@ -0,0 +1,131 @@
+package chains
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/memory"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// EmbeddingRouterChain is a router chain that picks the destination whose
+// description is the most similar to the input, by the cosine similarity of
+// their embeddings. Use it in a MultiRouteChain.
+type EmbeddingRouterChain struct {
+	embedder       embeddings.Embedder
+	names          []string
+	vectors        [][]float64
+	inputKey       string
+	scoreThreshold float64
+	memory         schema.Memory
+}
+
+var _ Chain = &EmbeddingRouterChain{}
+
+// NewEmbeddingRouterChain creates a router chain for the "input" input value,
+// and embeds the descriptions of the destinations with the embedder.
+func NewEmbeddingRouterChain(
+	ctx context.Context,
+	embedder embeddings.Embedder,
+	destinations []Destination,
+	opts ...EmbeddingRouterChainOption,
+) (*EmbeddingRouterChain, error) {
+	if err := validateDestinations(destinations); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(destinations))
+	descriptions := make([]string, 0, len(destinations))
+	for _, d := range destinations {
+		names = append(names, d.Name)
+		descriptions = append(descriptions, d.Description)
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, descriptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(destinations) {
+		return nil, fmt.Errorf("%w: got %d embeddings for %d destinations",
+			ErrChainInitialization, len(vectors), len(destinations))
+	}
+
+	c := &EmbeddingRouterChain{
+		embedder: embedder,
+		names:    names,
+		vectors:  vectors,
+		inputKey: input,
+		memory:   memory.NewSimple(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Call picks the destination most similar to the input. This method should not
+// be called directly. Use rather the Call, Run or Predict functions that
+// handles the memory and other aspects of the chain.
+func (c *EmbeddingRouterChain) Call(ctx context.Context, values map[string]any, _ ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	query, ok := values[c.inputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidInputValues, ErrInputValuesWrongType)
+	}
+
+	vector, err := c.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	destination := ""
+	var bestScore float64
+	for i, v := range c.vectors {
+		score, err := embeddings.CosineSimilarity(vector, v)
+		if err != nil {
+			return nil, err
+		}
+		if destination == "" || score > bestScore {
+			destination, bestScore = c.names[i], score
+		}
+	}
+	if c.scoreThreshold != 0 && bestScore < c.scoreThreshold {
+		destination = ""
+	}
+
+	return routerOutputs(destination, nil), nil
+}
+
+// GetMemory gets the memory of the chain.
+func (c *EmbeddingRouterChain) GetMemory() schema.Memory { //nolint:ireturn
+	return c.memory
+}
+
+// GetInputKeys returns the input key of the chain.
+func (c *EmbeddingRouterChain) GetInputKeys() []string {
+	return []string{c.inputKey}
+}
+
+// GetOutputKeys returns the destination and next inputs keys.
+func (c *EmbeddingRouterChain) GetOutputKeys() []string {
+	return []string{_routerDestinationKey, _routerNextInputsKey}
+}
+
+// EmbeddingRouterChainOption is an option of an EmbeddingRouterChain.
+type EmbeddingRouterChainOption func(*EmbeddingRouterChain)
+
+// WithRouterInputKey sets the input key of the text to route. It defaults to
+// "input".
+func WithRouterInputKey(inputKey string) EmbeddingRouterChainOption {
+	return func(c *EmbeddingRouterChain) {
+		c.inputKey = inputKey
+	}
+}
+
+// WithRouterScoreThreshold sets the lowest similarity the input must have with
+// the best destination to be routed to it. Inputs below it are routed to the
+// default chain. Zero, the default, always routes to the best destination.
+func WithRouterScoreThreshold(threshold float64) EmbeddingRouterChainOption {
+	return func(c *EmbeddingRouterChain) {
+		c.scoreThreshold = threshold
+	}
+}
@@ -0,0 +1,171 @@
+package chains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/outputparser"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const _llmRouterPrompt = `Given a raw text input to a language model, select the destination best suited for the input. You will be given the names of the available destinations and a description of what each destination is best suited for. You may also revise the original input if you think that revising it will ultimately lead to a better response.
+
+<< FORMATTING >>
+Return a markdown code snippet with a JSON object formatted to look like:
+` + "```json" + `
+{
+	"destination": string \ name of the destination to use or "DEFAULT"
+	"next_inputs": string \ a potentially modified version of the original input
+}
+` + "```" + `
+
+REMEMBER: "destination" MUST be one of the candidate destination names specified below OR it can be "DEFAULT" if the input is not well suited for any of the candidate destinations.
+REMEMBER: "next_inputs" can just be the original input if you don't think any modifications are needed.
+
+<< CANDIDATE DESTINATIONS >>
+{{.destinations}}
+
+<< INPUT >>
+{{.input}}
+
+<< OUTPUT >>
+` //nolint:lll
+
+// LLMRouterChain is a router chain that asks an llm to pick the destination of
+// the input, and optionally to rewrite it. Use it in a MultiRouteChain.
+type LLMRouterChain struct {
+	LLMChain *LLMChain
+}
+
+var _ Chain = LLMRouterChain{}
+
+// NewLLMRouterChain creates a router chain asking the llm to pick one of the
+// destinations by their description for the "input" input value.
+func NewLLMRouterChain(llm llms.LanguageModel, destinations []Destination) (LLMRouterChain, error) {
+	if err := validateDestinations(destinations); err != nil {
+		return LLMRouterChain{}, err
+	}
+
+	lines := make([]string, 0, len(destinations))
+	for _, d := range destinations {
+		lines = append(lines, fmt.Sprintf("%s: %s", d.Name, d.Description))
+	}
+
+	p := prompts.NewPromptTemplate(_llmRouterPrompt, []string{input})
+	p.PartialVariables = map[string]any{"destinations": strings.Join(lines, "\n")}
+	c := NewLLMChain(llm, p)
+	c.OutputParser = routerOutputParser{inputKey: input}
+
+	return LLMRouterChain{LLMChain: c}, nil
+}
+
+// NewMultiPromptChain creates a chain that asks the llm which of the
+// destinations suits the "input" input value best, and calls it. Inputs suited
+// for none of them are sent to the default chain, or answered by the llm as is
+// if the default chain is nil.
+func NewMultiPromptChain(llm llms.LanguageModel, destinations []Destination, defaultChain Chain) (*MultiRouteChain, error) { //nolint:lll
+	router, err := NewLLMRouterChain(llm, destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultChain == nil {
+		defaultChain = NewLLMChain(llm, prompts.NewPromptTemplate("{{.input}}", []string{input}))
+	}
+	return NewMultiRouteChain(router, destinations, defaultChain)
+}
+
+// Call asks the llm to pick the destination. This method should not be called
+// directly. Use rather the Call, Run or Predict functions that handles the
+// memory and other aspects of the chain.
+func (c LLMRouterChain) Call(ctx context.Context, values map[string]any, options ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	outputs, err := Call(ctx, c.LLMChain, values, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	route, ok := outputs[c.LLMChain.OutputKey].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: router output is not a route", ErrInvalidOutputValues)
+	}
+	return route, nil
+}
+
+// GetMemory returns the memory of the llm chain.
+func (c LLMRouterChain) GetMemory() schema.Memory { //nolint:ireturn
+	return c.LLMChain.GetMemory()
+}
+
+// GetInputKeys returns the input keys of the llm chain.
+func (c LLMRouterChain) GetInputKeys() []string {
+	return c.LLMChain.GetInputKeys()
+}
+
+// GetOutputKeys returns the destination and next inputs keys.
+func (c LLMRouterChain) GetOutputKeys() []string {
+	return []string{_routerDestinationKey, _routerNextInputsKey}
+}
+
+// routerOutputParser parses the JSON route of the llm of an LLMRouterChain into
+// router outputs. A rewritten input replaces the input value of inputKey.
+type routerOutputParser struct {
+	inputKey string
+}
+
+var _ schema.OutputParser[any] = routerOutputParser{}
+
+func (p routerOutputParser) Parse(text string) (any, error) {
+	jsonText := text
+	if _, afterStart, ok := strings.Cut(text, "```json"); ok {
+		jsonText, _, _ = strings.Cut(afterStart, "```")
+	} else if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		jsonText = text[start : end+1]
+	}
+
+	var route struct {
+		Destination string `json:"destination"`
+		NextInputs  any    `json:"next_inputs"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &route); err != nil {
+		return nil, outputparser.ParseError{Text: text, Reason: err.Error()}
+	}
+
+	destination := strings.TrimSpace(route.Destination)
+	if destination == "" {
+		return nil, outputparser.ParseError{Text: text, Reason: "no destination in output"}
+	}
+	if strings.EqualFold(destination, _routerDefaultDestination) {
+		destination = ""
+	}
+
+	var nextInputs map[string]any
+	switch next := route.NextInputs.(type) {
+	case string:
+		if next != "" {
+			nextInputs = map[string]any{p.inputKey: next}
+		}
+	case map[string]any:
+		nextInputs = next
+	case nil:
+	default:
+		return nil, outputparser.ParseError{Text: text, Reason: "next_inputs is not a string or an object"}
+	}
+
+	return routerOutputs(destination, nextInputs), nil
+}
+
+func (p routerOutputParser) ParseWithPrompt(text string, _ schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+func (p routerOutputParser) GetFormatInstructions() string {
+	return "Return a markdown code snippet with a JSON object with the destination and next_inputs keys."
+}
+
+func (p routerOutputParser) Type() string {
+	return "router_output_parser"
+}
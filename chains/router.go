@@ -0,0 +1,193 @@
+package chains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/memory"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const (
+	// _routerDestinationKey is the output key of router chains holding the name
+	// of the destination, or an empty string for the default chain.
+	_routerDestinationKey = "destination"
+	// _routerNextInputsKey is the output key of router chains holding the inputs
+	// that replace the ones of the destination, if any.
+	_routerNextInputsKey = "next_inputs"
+	// _routerDefaultDestination is the destination routers pick for the default
+	// chain.
+	_routerDefaultDestination = "DEFAULT"
+)
+
+var (
+	// ErrUnknownDestination is returned when a router picks a destination that
+	// does not exist.
+	ErrUnknownDestination = errors.New("unknown destination")
+	// ErrNoDefaultChain is returned when a router picks the default chain of a
+	// multi route chain without one.
+	ErrNoDefaultChain = errors.New("no default chain")
+)
+
+// Destination is a chain inputs can be routed to. Routers pick the destination
+// by its description.
+type Destination struct {
+	// Name is the name of the destination, unique among the destinations.
+	Name string
+	// Description describes the inputs the destination is best suited for.
+	Description string
+	// Chain is the chain the inputs are routed to.
+	Chain Chain
+}
+
+// MultiRouteChain is a chain that calls a router chain to pick one of several
+// destination chains, and calls it with the inputs. Router chains, such as
+// LLMRouterChain and EmbeddingRouterChain, return the name of the destination
+// under the "destination" key, empty for the default chain, and inputs that
+// replace some of the original ones under the "next_inputs" key.
+type MultiRouteChain struct {
+	router       Chain
+	destinations map[string]Chain
+	defaultChain Chain
+	outputKeys   []string
+	memory       schema.Memory
+}
+
+var _ Chain = &MultiRouteChain{}
+
+// NewMultiRouteChain creates a chain that routes inputs with the router to the
+// destinations, and to the default chain if the router picks none. The default
+// chain can be nil, in which case such inputs fail with ErrNoDefaultChain. The
+// output keys of the chain are the ones all destinations have in common.
+func NewMultiRouteChain(router Chain, destinations []Destination, defaultChain Chain) (*MultiRouteChain, error) {
+	if err := validateDestinations(destinations); err != nil {
+		return nil, err
+	}
+
+	chains := make(map[string]Chain, len(destinations))
+	for _, d := range destinations {
+		if d.Chain == nil {
+			return nil, fmt.Errorf("%w: destination %s has no chain", ErrChainInitialization, d.Name)
+		}
+		chains[d.Name] = d.Chain
+	}
+
+	outputKeys := destinations[0].Chain.GetOutputKeys()
+	for _, d := range destinations[1:] {
+		outputKeys = commonKeys(outputKeys, d.Chain.GetOutputKeys())
+	}
+	if defaultChain != nil {
+		outputKeys = commonKeys(outputKeys, defaultChain.GetOutputKeys())
+	}
+
+	return &MultiRouteChain{
+		router:       router,
+		destinations: chains,
+		defaultChain: defaultChain,
+		outputKeys:   outputKeys,
+		memory:       memory.NewSimple(),
+	}, nil
+}
+
+// Call routes the inputs and calls the destination chain with them. This method
+// should not be called directly. Use rather the Call, Run or Predict functions
+// that handles the memory and other aspects of the chain.
+func (c *MultiRouteChain) Call(ctx context.Context, inputs map[string]any, options ...ChainCallOption) (map[string]any, error) { //nolint:lll
+	route, err := Call(ctx, c.router, inputs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, ok := route[_routerDestinationKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: router returned no destination", ErrInvalidOutputValues)
+	}
+
+	nextInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		nextInputs[key] = value
+	}
+	if rewritten, ok := route[_routerNextInputsKey].(map[string]any); ok {
+		for key, value := range rewritten {
+			nextInputs[key] = value
+		}
+	}
+
+	if destination == "" {
+		if c.defaultChain == nil {
+			return nil, ErrNoDefaultChain
+		}
+		return Call(ctx, c.defaultChain, nextInputs, options...)
+	}
+
+	chain, ok := c.destinations[destination]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDestination, destination)
+	}
+	return Call(ctx, chain, nextInputs, options...)
+}
+
+// GetMemory gets the memory of the chain.
+func (c *MultiRouteChain) GetMemory() schema.Memory { //nolint:ireturn
+	return c.memory
+}
+
+// GetInputKeys returns the input keys of the router.
+func (c *MultiRouteChain) GetInputKeys() []string {
+	return c.router.GetInputKeys()
+}
+
+// GetOutputKeys returns the output keys all destinations have in common.
+func (c *MultiRouteChain) GetOutputKeys() []string {
+	return c.outputKeys
+}
+
+// validateDestinations checks that there are destinations and that each can be
+// picked by a router.
+func validateDestinations(destinations []Destination) error {
+	if len(destinations) == 0 {
+		return fmt.Errorf("%w: no destinations", ErrChainInitialization)
+	}
+
+	names := make(map[string]struct{}, len(destinations))
+	for _, d := range destinations {
+		if strings.TrimSpace(d.Name) == "" {
+			return fmt.Errorf("%w: destination without a name", ErrChainInitialization)
+		}
+		if strings.EqualFold(d.Name, _routerDefaultDestination) {
+			return fmt.Errorf("%w: destination name %s is reserved", ErrChainInitialization, d.Name)
+		}
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("%w: duplicate destination %s", ErrChainInitialization, d.Name)
+		}
+		names[d.Name] = struct{}{}
+	}
+	return nil
+}
+
+// routerOutputs returns the outputs of a router chain picking the destination.
+func routerOutputs(destination string, nextInputs map[string]any) map[string]any {
+	if nextInputs == nil {
+		nextInputs = map[string]any{}
+	}
+	return map[string]any{
+		_routerDestinationKey: destination,
+		_routerNextInputsKey:  nextInputs,
+	}
+}
+
+// commonKeys returns the keys of a that are also in b, in the order of a.
+func commonKeys(a, b []string) []string {
+	common := make([]string, 0, len(a))
+	for _, key := range a {
+		for _, other := range b {
+			if key == other {
+				common = append(common, key)
+				break
+			}
+		}
+	}
+	return common
+}
@@ -0,0 +1,198 @@
+package chains
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/llms/fake"
+	"github.com/aresa7796/langchaingo/outputparser"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/stretchr/testify/require"
+)
+
+func testDestinations(physics, math *testLanguageModel) []Destination {
+	return []Destination{
+		{
+			Name:        "physics",
+			Description: "Good for answering questions about physics",
+			Chain:       NewLLMChain(physics, prompts.NewPromptTemplate("Physics: {{.input}}", []string{"input"})),
+		},
+		{
+			Name:        "math",
+			Description: "Good for answering math questions",
+			Chain:       NewLLMChain(math, prompts.NewPromptTemplate("Math: {{.input}}", []string{"input"})),
+		},
+	}
+}
+
+func TestMultiPromptChain(t *testing.T) {
+	t.Parallel()
+
+	physics := &testLanguageModel{expResult: "radiation of a black body"}
+	math := &testLanguageModel{expResult: "4"}
+	router := fake.NewLLM(fake.WithTexts(
+		"```json\n{\n\t\"destination\": \"physics\",\n\t\"next_inputs\": \"What is black body radiation?\"\n}\n```",
+	))
+	c, err := NewMultiPromptChain(router, testDestinations(physics, math), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"text"}, c.GetOutputKeys())
+
+	result, err := Run(context.Background(), c, "black body radiation?")
+	require.NoError(t, err)
+	require.Equal(t, "radiation of a black body", result)
+
+	// The destinations are described to the router, and the destination gets
+	// the rewritten input.
+	routerPrompt := router.Calls()[0].Prompt
+	require.Contains(t, routerPrompt, "physics: Good for answering questions about physics")
+	require.Contains(t, routerPrompt, "math: Good for answering math questions")
+	require.Contains(t, routerPrompt, "<< INPUT >>\nblack body radiation?")
+	require.Equal(t, "Physics: What is black body radiation?", physics.recordedPrompt[0].String())
+	require.Nil(t, math.recordedPrompt)
+}
+
+func TestMultiPromptChainDefault(t *testing.T) {
+	t.Parallel()
+
+	router := fake.NewLLM(fake.WithTexts(
+		`{"destination": "DEFAULT", "next_inputs": ""}`,
+		"Paris",
+	))
+	c, err := NewMultiPromptChain(router, testDestinations(&testLanguageModel{}, &testLanguageModel{}), nil)
+	require.NoError(t, err)
+
+	result, err := Run(context.Background(), c, "What is the capital of France?")
+	require.NoError(t, err)
+	require.Equal(t, "Paris", result)
+	require.Equal(t, "What is the capital of France?", router.Calls()[1].Prompt)
+}
+
+func TestMultiRouteChainErrors(t *testing.T) {
+	t.Parallel()
+
+	destinations := testDestinations(&testLanguageModel{}, &testLanguageModel{})
+
+	router, err := NewLLMRouterChain(fake.NewLLM(fake.WithTexts(`{"destination": "chemistry"}`)), destinations)
+	require.NoError(t, err)
+	c, err := NewMultiRouteChain(router, destinations, nil)
+	require.NoError(t, err)
+	_, err = Run(context.Background(), c, "What is an acid?")
+	require.ErrorIs(t, err, ErrUnknownDestination)
+
+	router, err = NewLLMRouterChain(fake.NewLLM(fake.WithTexts(`{"destination": "DEFAULT"}`)), destinations)
+	require.NoError(t, err)
+	c, err = NewMultiRouteChain(router, destinations, nil)
+	require.NoError(t, err)
+	_, err = Run(context.Background(), c, "Hello")
+	require.ErrorIs(t, err, ErrNoDefaultChain)
+}
+
+func TestNewMultiRouteChainValidation(t *testing.T) {
+	t.Parallel()
+
+	chain := NewLLMChain(&testLanguageModel{}, prompts.NewPromptTemplate("{{.input}}", []string{"input"}))
+	testCases := []struct {
+		name         string
+		destinations []Destination
+	}{
+		{name: "no destinations"},
+		{name: "no name", destinations: []Destination{{Chain: chain}}},
+		{name: "reserved name", destinations: []Destination{{Name: "default", Chain: chain}}},
+		{name: "duplicate name", destinations: []Destination{{Name: "a", Chain: chain}, {Name: "a", Chain: chain}}},
+		{name: "no chain", destinations: []Destination{{Name: "a"}}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMultiRouteChain(chain, tc.destinations, nil)
+			require.ErrorIs(t, err, ErrChainInitialization)
+		})
+	}
+}
+
+func TestRouterOutputParser(t *testing.T) {
+	t.Parallel()
+
+	p := routerOutputParser{inputKey: "input"}
+	testCases := []struct {
+		name     string
+		text     string
+		expected map[string]any
+	}{
+		{
+			name:     "fenced",
+			text:     "Sure!\n```json\n{\"destination\": \" math \", \"next_inputs\": \"2 + 2\"}\n```",
+			expected: map[string]any{"destination": "math", "next_inputs": map[string]any{"input": "2 + 2"}},
+		},
+		{
+			name:     "bare",
+			text:     `The route is {"destination": "default"}.`,
+			expected: map[string]any{"destination": "", "next_inputs": map[string]any{}},
+		},
+		{
+			name: "object next inputs",
+			text: `{"destination": "math", "next_inputs": {"input": "2 + 2", "level": "easy"}}`,
+			expected: map[string]any{
+				"destination": "math",
+				"next_inputs": map[string]any{"input": "2 + 2", "level": "easy"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := p.Parse(tc.text)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, parsed)
+		})
+	}
+
+	for _, text := range []string{"physics", `{"next_inputs": "2 + 2"}`, `{"destination": "math", "next_inputs": 4}`} {
+		_, err := p.Parse(text)
+		require.ErrorAs(t, err, &outputparser.ParseError{})
+	}
+}
+
+func TestEmbeddingRouterChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	physics := &testLanguageModel{expResult: "physics answer"}
+	math := &testLanguageModel{expResult: "math answer"}
+	destinations := testDestinations(physics, math)
+	destinations[0].Description = "gravity light energy mass physics"
+	destinations[1].Description = "algebra numbers equations math"
+
+	router, err := NewEmbeddingRouterChain(ctx, fake.NewEmbedder(0), destinations)
+	require.NoError(t, err)
+	c, err := NewMultiRouteChain(router, destinations,
+		NewLLMChain(&testLanguageModel{expResult: "default answer"}, prompts.NewPromptTemplate("{{.input}}", []string{"input"})),
+	)
+	require.NoError(t, err)
+
+	result, err := Run(ctx, c, "how do numbers and equations work")
+	require.NoError(t, err)
+	require.Equal(t, "math answer", result)
+
+	result, err = Run(ctx, c, "why does light have energy")
+	require.NoError(t, err)
+	require.Equal(t, "physics answer", result)
+	require.Equal(t, "Physics: why does light have energy", physics.recordedPrompt[0].String())
+
+	router, err = NewEmbeddingRouterChain(ctx, fake.NewEmbedder(0), destinations, WithRouterScoreThreshold(0.99))
+	require.NoError(t, err)
+	c, err = NewMultiRouteChain(router, destinations,
+		NewLLMChain(&testLanguageModel{expResult: "default answer"}, prompts.NewPromptTemplate("{{.input}}", []string{"input"})),
+	)
+	require.NoError(t, err)
+
+	result, err = Run(ctx, c, "why does light have energy")
+	require.NoError(t, err)
+	require.Equal(t, "default answer", result)
+}
@@ -81,3 +81,23 @@ func getNorm(v []float64) float64 {
 
 	return math.Sqrt(sum)
 }
+
+// CosineSimilarity returns the cosine of the angle between the vectors a and b.
+// If one of the vectors has the norm zero, zero is returned.
+func CosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrVectorsNotSameSize
+	}
+
+	var dot float64
+	for i := 0; i < len(a); i++ {
+		dot += a[i] * b[i]
+	}
+
+	norms := getNorm(a) * getNorm(b)
+	if norms == 0 {
+		return 0, nil
+	}
+
+	return dot / norms, nil
+}
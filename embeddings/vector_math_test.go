@@ -81,3 +81,26 @@ func TestGetNorm(t *testing.T) {
 		assert.Equal(t, tc.expected, getNorm(tc.vector))
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		a, b     []float64
+		expected float64
+	}{
+		{a: []float64{1, 0}, b: []float64{1, 0}, expected: 1},
+		{a: []float64{1, 0}, b: []float64{0, 1}, expected: 0},
+		{a: []float64{1, 0}, b: []float64{-2, 0}, expected: -1},
+		{a: []float64{0, 0}, b: []float64{1, 1}, expected: 0},
+	}
+
+	for _, tc := range cases {
+		similarity, err := CosineSimilarity(tc.a, tc.b)
+		assert.NoError(t, err)
+		assert.InDelta(t, tc.expected, similarity, 1e-9)
+	}
+
+	_, err := CosineSimilarity([]float64{1}, []float64{1, 2})
+	assert.ErrorIs(t, err, ErrVectorsNotSameSize)
+}
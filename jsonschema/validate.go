@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidValue is returned when a value does not match a definition.
+var ErrInvalidValue = errors.New("value does not match schema")
+
+// Validate checks that a decoded JSON value matches the definition. The value
+// is expected to be in the form produced by encoding/json when decoding into an
+// any: map[string]any for objects, []any for arrays, float64 for numbers and so
+// on. Only the keywords of Definition are checked. The returned error wraps
+// ErrInvalidValue and describes the first violation that was found.
+func Validate(def Definition, value any) error {
+	return validate(def, value, "$")
+}
+
+func validate(def Definition, value any, path string) error { //nolint:cyclop
+	if err := validateType(def.Type, value, path); err != nil {
+		return err
+	}
+
+	if len(def.Enum) > 0 {
+		if err := validateEnum(def.Enum, value, path); err != nil {
+			return err
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range def.Required {
+			if _, ok := v[key]; !ok {
+				return fmt.Errorf("%w: %s: missing required property %q", ErrInvalidValue, path, key)
+			}
+		}
+		for key, propertyDef := range def.Properties {
+			propertyValue, ok := v[key]
+			if !ok {
+				continue
+			}
+			if err := validate(propertyDef, propertyValue, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if def.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := validate(*def.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateType(dataType DataType, value any, path string) error {
+	var ok bool
+	switch dataType {
+	case "":
+		return nil
+	case Object:
+		_, ok = value.(map[string]any)
+	case Array:
+		_, ok = value.([]any)
+	case String:
+		_, ok = value.(string)
+	case Number:
+		_, ok = value.(float64)
+	case Integer:
+		var f float64
+		f, ok = value.(float64)
+		ok = ok && f == math.Trunc(f)
+	case Boolean:
+		_, ok = value.(bool)
+	case Null:
+		ok = value == nil
+	default:
+		return fmt.Errorf("%w: %s: unknown type %q", ErrInvalidValue, path, dataType)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s: expected %s, got %s", ErrInvalidValue, path, dataType, typeName(value))
+	}
+
+	return nil
+}
+
+func validateEnum(enum []string, value any, path string) error {
+	s, ok := value.(string)
+	if ok {
+		for _, e := range enum {
+			if s == e {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s: %v is not one of %q", ErrInvalidValue, path, value, enum)
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case nil:
+		return string(Null)
+	case map[string]any:
+		return string(Object)
+	case []any:
+		return string(Array)
+	case string:
+		return string(String)
+	case float64:
+		return string(Number)
+	case bool:
+		return string(Boolean)
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,59 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/jsonschema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	def := jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"query": {Type: jsonschema.String},
+			"limit": {Type: jsonschema.Integer},
+			"unit":  {Type: jsonschema.String, Enum: []string{"celsius", "fahrenheit"}},
+			"tags": {
+				Type:  jsonschema.Array,
+				Items: &jsonschema.Definition{Type: jsonschema.String},
+			},
+		},
+		Required: []string{"query"},
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{name: "valid", input: `{"query": "go", "limit": 3, "unit": "celsius", "tags": ["a", "b"]}`, valid: true},
+		{name: "only required", input: `{"query": "go"}`, valid: true},
+		{name: "missing required", input: `{"limit": 3}`},
+		{name: "wrong type", input: `{"query": 1}`},
+		{name: "not an integer", input: `{"query": "go", "limit": 1.5}`},
+		{name: "not in enum", input: `{"query": "go", "unit": "kelvin"}`},
+		{name: "wrong item type", input: `{"query": "go", "tags": ["a", 2]}`},
+		{name: "not an object", input: `"go"`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var value any
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &value))
+
+			err := jsonschema.Validate(def, value)
+			if tc.valid {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, jsonschema.ErrInvalidValue)
+		})
+	}
+}
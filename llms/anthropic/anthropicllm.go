@@ -3,11 +3,13 @@ package anthropic
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/llms/anthropic/internal/anthropicclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
 	"github.com/aresa7796/langchaingo/schema"
 )
 
@@ -38,7 +40,8 @@ func New(opts ...Option) (*LLM, error) {
 
 func newClient(opts ...Option) (*anthropicclient.Client, error) {
 	options := &options{
-		token: os.Getenv(tokenEnvVarName),
+		token:      os.Getenv(tokenEnvVarName),
+		httpClient: http.DefaultClient,
 	}
 
 	for _, opt := range opts {
@@ -49,7 +52,16 @@ func newClient(opts ...Option) (*anthropicclient.Client, error) {
 		return nil, ErrMissingToken
 	}
 
-	return anthropicclient.New(options.token, options.model)
+	clientOptions := []anthropicclient.Option{}
+	if options.baseURL != "" {
+		clientOptions = append(clientOptions, anthropicclient.WithBaseURL(options.baseURL))
+	}
+	if options.retryPolicy != nil {
+		options.httpClient = retry.NewDoer(options.httpClient, *options.retryPolicy)
+	}
+	clientOptions = append(clientOptions, anthropicclient.WithHTTPClient(options.httpClient))
+
+	return anthropicclient.New(options.token, options.model, clientOptions...)
 }
 
 // Call requests a completion for the given prompt.
@@ -65,14 +77,17 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 }
 
 func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, prompts)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
 	}
 
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 
 	generations := make([]*llms.Generation, 0, len(prompts))
 	for _, prompt := range prompts {
@@ -86,6 +101,9 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 			StreamingFunc: opts.StreamingFunc,
 		})
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 		generations = append(generations, &llms.Generation{
@@ -93,8 +111,8 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 		})
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 	return generations, nil
 }
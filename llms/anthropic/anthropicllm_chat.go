@@ -0,0 +1,290 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/anthropic/internal/anthropicclient"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// ErrUnmatchedFunctionMessage is returned when a function message does not
+// follow a call to the function.
+var ErrUnmatchedFunctionMessage = errors.New("function message without a matching function call")
+
+// Chat is a chat LLM using the Anthropic Messages API.
+type Chat struct {
+	CallbacksHandler callbacks.Handler
+	client           *anthropicclient.Client
+}
+
+var (
+	_ llms.ChatLLM       = (*Chat)(nil)
+	_ llms.LanguageModel = (*Chat)(nil)
+)
+
+// NewChat returns a new Anthropic chat LLM.
+func NewChat(opts ...Option) (*Chat, error) {
+	c, err := newClient(opts...)
+	return &Chat{
+		client: c,
+	}, err
+}
+
+// Call requests a chat response for the given messages.
+func (o *Chat) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { // nolint: lll
+	r, err := o.Generate(ctx, [][]schema.ChatMessage{messages}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	return r[0].Message, nil
+}
+
+// Generate requests a chat response for each set of messages. Tools use is
+// returned as tool calls, and the first tool use also as the function call of
+// the message.
+func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { // nolint:lll
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
+	}
+
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
+
+	generations := make([]*llms.Generation, 0, len(messageSets))
+	for _, messageSet := range messageSets {
+		system, messages, err := messagesToClientMessages(messageSet)
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		tools := toolsFromOptions(opts)
+		req := &anthropicclient.MessagesRequest{
+			Model:         opts.Model,
+			System:        system,
+			Messages:      messages,
+			MaxTokens:     opts.MaxTokens,
+			Temperature:   opts.Temperature,
+			TopP:          opts.TopP,
+			TopK:          opts.TopK,
+			StopWords:     opts.StopWords,
+			Tools:         tools,
+			StreamingFunc: opts.StreamingFunc,
+		}
+		if len(tools) > 0 {
+			req.ToolChoice = toolChoiceFromOptions(opts)
+		}
+
+		result, err := o.client.CreateMessage(ctx, req)
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		msg := clientContentToMessage(result.Content)
+		generations = append(generations, &llms.Generation{
+			Message: msg,
+			Text:    msg.Content,
+			GenerationInfo: map[string]any{
+				"PromptTokens":     result.Usage.InputTokens,
+				"CompletionTokens": result.Usage.OutputTokens,
+				"TotalTokens":      result.Usage.InputTokens + result.Usage.OutputTokens,
+				"StopReason":       result.StopReason,
+			},
+			Usage: llms.NewUsage(result.Model, result.Usage.InputTokens, result.Usage.OutputTokens),
+		})
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+func (o *Chat) GetNumTokens(text string) int {
+	return llms.CountTokens(o.client.Model, text)
+}
+
+func (o *Chat) GeneratePrompt(ctx context.Context, promptValues []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GenerateChatPrompt(ctx, o, promptValues, options...)
+}
+
+// messagesToClientMessages maps the messages onto the system prompt and the
+// content blocks of the Messages API. Consecutive messages of the same role are
+// merged, as the API expects the roles to alternate. Function calls have no id,
+// so one is made up and function messages are matched to the last call of the
+// function without a result.
+func messagesToClientMessages(messages []schema.ChatMessage) (string, []*anthropicclient.ChatMessage, error) { // nolint:cyclop,lll
+	var system []string
+	clientMessages := make([]*anthropicclient.ChatMessage, 0, len(messages))
+	pendingCalls := map[string][]string{}
+	numCalls := 0
+
+	add := func(role string, blocks ...*anthropicclient.ContentBlock) {
+		if n := len(clientMessages); n > 0 && clientMessages[n-1].Role == role {
+			clientMessages[n-1].Content = append(clientMessages[n-1].Content, blocks...)
+			return
+		}
+		clientMessages = append(clientMessages, &anthropicclient.ChatMessage{Role: role, Content: blocks})
+	}
+
+	for _, m := range messages {
+		switch m := m.(type) {
+		case schema.SystemChatMessage:
+			system = append(system, m.Content)
+		case schema.AIChatMessage:
+			blocks := make([]*anthropicclient.ContentBlock, 0, 1+len(m.ToolCalls))
+			if m.Content != "" {
+				blocks = append(blocks, &anthropicclient.ContentBlock{Type: "text", Text: m.Content})
+			}
+			calls := m.ToolCalls
+			if len(calls) == 0 && m.FunctionCall != nil {
+				numCalls++
+				id := fmt.Sprintf("toolu_%d", numCalls)
+				pendingCalls[m.FunctionCall.Name] = append(pendingCalls[m.FunctionCall.Name], id)
+				calls = []schema.ToolCall{{ID: id, Type: "function", FunctionCall: m.FunctionCall}}
+			}
+			for _, call := range calls {
+				if call.FunctionCall == nil {
+					continue
+				}
+				blocks = append(blocks, &anthropicclient.ContentBlock{
+					Type:  "tool_use",
+					ID:    call.ID,
+					Name:  call.FunctionCall.Name,
+					Input: toolInput(call.FunctionCall.Arguments),
+				})
+			}
+			if len(blocks) > 0 {
+				add(RoleAssistant, blocks...)
+			}
+		case schema.FunctionChatMessage:
+			ids := pendingCalls[m.Name]
+			if len(ids) == 0 {
+				return "", nil, fmt.Errorf("%w: %s", ErrUnmatchedFunctionMessage, m.Name)
+			}
+			pendingCalls[m.Name] = ids[:len(ids)-1]
+			add(RoleUser, &anthropicclient.ContentBlock{
+				Type:      "tool_result",
+				ToolUseID: ids[len(ids)-1],
+				Content:   m.Content,
+			})
+		case schema.ToolChatMessage:
+			add(RoleUser, &anthropicclient.ContentBlock{Type: "tool_result", ToolUseID: m.ID, Content: m.Content})
+		default:
+			add(RoleUser, &anthropicclient.ContentBlock{Type: "text", Text: m.GetContent()})
+		}
+	}
+
+	return strings.Join(system, "\n"), clientMessages, nil
+}
+
+// toolInput returns the arguments of a function call as the input of a tool
+// use block, which must be a JSON object.
+func toolInput(arguments string) json.RawMessage {
+	if json.Valid([]byte(arguments)) && strings.HasPrefix(strings.TrimSpace(arguments), "{") {
+		return json.RawMessage(arguments)
+	}
+	input, _ := json.Marshal(map[string]string{"__arg1": arguments}) // nolint:errchkjson
+	return input
+}
+
+func toolsFromOptions(opts llms.CallOptions) []anthropicclient.Tool {
+	tools := make([]anthropicclient.Tool, 0, len(opts.Functions)+len(opts.Tools))
+	for _, fn := range opts.Functions {
+		tools = append(tools, anthropicclient.Tool{
+			Name:        fn.Name,
+			Description: fn.Description,
+			InputSchema: fn.Parameters,
+		})
+	}
+	for _, tool := range opts.Tools {
+		if tool.Function == nil {
+			continue
+		}
+		tools = append(tools, anthropicclient.Tool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: tool.Function.Parameters,
+		})
+	}
+	return tools
+}
+
+func toolChoiceFromOptions(opts llms.CallOptions) *anthropicclient.ToolChoice {
+	choice := string(opts.ToolChoice)
+	if choice == "" {
+		choice = string(opts.FunctionCallBehavior)
+	}
+
+	switch choice {
+	case "", string(llms.ToolChoiceAuto):
+		return nil
+	case string(llms.ToolChoiceNone):
+		return &anthropicclient.ToolChoice{Type: "none"}
+	case string(llms.ToolChoiceRequired):
+		return &anthropicclient.ToolChoice{Type: "any"}
+	}
+	return &anthropicclient.ToolChoice{Type: "tool", Name: choice}
+}
+
+func clientContentToMessage(content []*anthropicclient.ContentBlock) *schema.AIChatMessage {
+	msg := &schema.AIChatMessage{}
+	var text strings.Builder
+	for _, block := range content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "tool_use":
+			call := schema.ToolCall{
+				ID:           block.ID,
+				Type:         "function",
+				FunctionCall: &schema.FunctionCall{Name: block.Name, Arguments: string(block.Input)},
+			}
+			if call.FunctionCall.Arguments == "" {
+				call.FunctionCall.Arguments = "{}"
+			}
+			msg.ToolCalls = append(msg.ToolCalls, call)
+		}
+	}
+	msg.Content = text.String()
+	if len(msg.ToolCalls) > 0 {
+		msg.FunctionCall = msg.ToolCalls[0].FunctionCall
+	}
+
+	return msg
+}
+
+func getPromptsFromMessageSets(messageSets [][]schema.ChatMessage) []string {
+	prompts := make([]string, 0, len(messageSets))
+	for i := 0; i < len(messageSets); i++ {
+		curPrompt := ""
+		for j := 0; j < len(messageSets[i]); j++ {
+			curPrompt += messageSets[i][j].GetContent()
+		}
+		prompts = append(prompts, curPrompt)
+	}
+
+	return prompts
+}
@@ -0,0 +1,161 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/anthropic/internal/anthropicclient"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChat returns a chat LLM talking to a stand-in of the Messages API
+// that records the request bodies and answers with the handler.
+func newTestChat(t *testing.T, requests *[]map[string]any, handler func(w http.ResponseWriter)) *Chat {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/messages", r.URL.Path)
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+		handler(w)
+	}))
+	t.Cleanup(server.Close)
+
+	chat, err := NewChat(WithToken("test"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+	return chat
+}
+
+func TestChat(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{"id":"msg_1","model":"claude-2.1","role":"assistant","stop_reason":"tool_use",
+			"content":[{"type":"text","text":"Let me check."},
+			{"type":"tool_use","id":"toolu_a","name":"weather","input":{"city":"Oslo"}}],
+			"usage":{"input_tokens":10,"output_tokens":5}}`))
+	})
+
+	generations, err := chat.Generate(context.Background(), [][]schema.ChatMessage{{
+		schema.SystemChatMessage{Content: "Be brief."},
+		schema.HumanChatMessage{Content: "Weather in Oslo?"},
+		schema.AIChatMessage{FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+		schema.FunctionChatMessage{Name: "weather", Content: "sunny"},
+		schema.HumanChatMessage{Content: "And Oslo?"},
+	}}, llms.WithFunctions([]llms.FunctionDefinition{{
+		Name:       "weather",
+		Parameters: map[string]any{"type": "object"},
+	}}), llms.WithMaxTokens(100))
+	require.NoError(t, err)
+
+	msg := generations[0].Message
+	require.Equal(t, "Let me check.", msg.Content)
+	require.Equal(t, &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Oslo"}`}, msg.FunctionCall)
+	require.Equal(t, "toolu_a", msg.ToolCalls[0].ID)
+	require.Equal(t, 15, generations[0].GenerationInfo["TotalTokens"])
+	require.Equal(t, llms.NewUsage("claude-2.1", 10, 5), generations[0].Usage)
+
+	req := requests[0]
+	require.Equal(t, "Be brief.", req["system"])
+	require.Equal(t, float64(100), req["max_tokens"])
+	require.NotContains(t, req, "tool_choice")
+	require.Equal(t, []any{map[string]any{"name": "weather", "input_schema": map[string]any{"type": "object"}}}, req["tools"])
+	require.Equal(t, []any{
+		map[string]any{"role": "user", "content": []any{map[string]any{"type": "text", "text": "Weather in Oslo?"}}},
+		map[string]any{"role": "assistant", "content": []any{map[string]any{
+			"type": "tool_use", "id": "toolu_1", "name": "weather", "input": map[string]any{"city": "Rome"},
+		}}},
+		map[string]any{"role": "user", "content": []any{
+			map[string]any{"type": "tool_result", "tool_use_id": "toolu_1", "content": "sunny"},
+			map[string]any{"type": "text", "text": "And Oslo?"},
+		}},
+	}, req["messages"])
+}
+
+func TestChatForcedFunction(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{"content":[{"type":"tool_use","id":"toolu_a","name":"search","input":{}}]}`))
+	})
+
+	msg, err := chat.Call(context.Background(), []schema.ChatMessage{schema.HumanChatMessage{Content: "Search"}},
+		llms.WithFunctions([]llms.FunctionDefinition{{Name: "search"}}),
+		llms.WithForcedFunction("search"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "search", msg.FunctionCall.Name)
+	require.Equal(t, map[string]any{"type": "tool", "name": "search"}, requests[0]["tool_choice"])
+
+	_, err = chat.Call(context.Background(), []schema.ChatMessage{schema.FunctionChatMessage{Name: "search"}})
+	require.ErrorIs(t, err, ErrUnmatchedFunctionMessage)
+}
+
+func TestChatStreaming(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		for _, event := range []string{
+			`{"type":"message_start","message":{"id":"msg_1","role":"assistant","content":[],"usage":{"input_tokens":7}}}`,
+			`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+			`{"type":"ping"}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" there"}}`,
+			`{"type":"content_block_stop","index":0}`,
+			`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_a","name":"f","input":{}}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"q\":"}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"1}"}}`,
+			`{"type":"content_block_stop","index":1}`,
+			`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":4}}`,
+			`{"type":"message_stop"}`,
+		} {
+			fmt.Fprintf(w, "event: x\ndata: %s\n\n", event)
+		}
+	})
+
+	var chunks []string
+	generations, err := chat.Generate(context.Background(),
+		[][]schema.ChatMessage{{schema.HumanChatMessage{Content: "Hi"}}},
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, true, requests[0]["stream"])
+	require.Equal(t, []string{"Hello", " there"}, chunks)
+	require.Equal(t, "Hello there", generations[0].Text)
+	require.Equal(t, `{"q":1}`, generations[0].Message.FunctionCall.Arguments)
+	require.Equal(t, 11, generations[0].GenerationInfo["TotalTokens"])
+	require.Equal(t, 11, generations[0].Usage.TotalTokens)
+	require.Equal(t, "tool_use", generations[0].GenerationInfo["StopReason"])
+}
+
+func TestChatStreamingInterrupted(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		for _, event := range []string{
+			`{"type":"message_start","message":{"id":"msg_1","role":"assistant","content":[],"usage":{"input_tokens":7}}}`,
+			`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}`,
+		} {
+			fmt.Fprintf(w, "event: x\ndata: %s\n\n", event)
+		}
+	})
+
+	_, err := chat.Generate(context.Background(),
+		[][]schema.ChatMessage{{schema.HumanChatMessage{Content: "Hi"}}},
+		llms.WithStreamingFunc(func(_ context.Context, _ []byte) error { return nil }))
+	require.ErrorIs(t, err, anthropicclient.ErrStreamInterrupted)
+}
@@ -1,12 +1,20 @@
 package anthropic
 
+import (
+	"github.com/aresa7796/langchaingo/llms/anthropic/internal/anthropicclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
 const (
 	tokenEnvVarName = "ANTHROPIC_API_KEY" //nolint:gosec
 )
 
 type options struct {
-	token string
-	model string
+	token       string
+	model       string
+	baseURL     string
+	httpClient  anthropicclient.Doer
+	retryPolicy *retry.Policy
 }
 
 type Option func(*options)
@@ -25,3 +33,28 @@ func WithModel(model string) Option {
 		opts.model = model
 	}
 }
+
+// WithBaseURL passes the base URL of the Anthropic API to the client. The
+// default is https://api.anthropic.com/v1.
+func WithBaseURL(baseURL string) Option {
+	return func(opts *options) {
+		opts.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient allows setting a custom HTTP client. If not set, the default value
+// is http.DefaultClient.
+func WithHTTPClient(client anthropicclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry retries the requests Anthropic rejects with a rate limit or an
+// overloaded or server error. The HTTP client of the LLM is wrapped with a
+// retry.Doer for the policy; see retry.Policy for its defaults.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
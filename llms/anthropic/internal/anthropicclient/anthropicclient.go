@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 )
 
 const (
@@ -39,6 +40,14 @@ func WithHTTPClient(client Doer) Option {
 	}
 }
 
+// WithBaseURL sets the base URL of the Anthropic API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
 // New returns a new Anthropic client.
 func New(token string, model string, opts ...Option) (*Client, error) {
 	c := &Client{
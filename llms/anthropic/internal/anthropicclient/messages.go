@@ -0,0 +1,255 @@
+package anthropicclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
+const (
+	defaultMessagesModel     = "claude-2.1"
+	defaultMessagesMaxTokens = 1024
+)
+
+// ErrUnexpectedStreamEvent is returned when an event of a streamed message
+// refers to a content block that was not started.
+var ErrUnexpectedStreamEvent = errors.New("unexpected stream event")
+
+// ErrStreamInterrupted is returned when a streamed message ends without its
+// message_stop event.
+var ErrStreamInterrupted = errors.New("stream ended before the message stopped")
+
+// MessagesRequest is a request to create a message.
+type MessagesRequest struct {
+	Model       string         `json:"model"`
+	System      string         `json:"system,omitempty"`
+	Messages    []*ChatMessage `json:"messages"`
+	MaxTokens   int            `json:"max_tokens"`
+	Temperature float64        `json:"temperature,omitempty"`
+	TopP        float64        `json:"top_p,omitempty"`
+	TopK        int            `json:"top_k,omitempty"`
+	StopWords   []string       `json:"stop_sequences,omitempty"`
+	Tools       []Tool         `json:"tools,omitempty"`
+	ToolChoice  *ToolChoice    `json:"tool_choice,omitempty"`
+	Stream      bool           `json:"stream,omitempty"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+}
+
+// ChatMessage is a message of a conversation.
+type ChatMessage struct {
+	// Role is "user" or "assistant".
+	Role    string          `json:"role"`
+	Content []*ContentBlock `json:"content"`
+}
+
+// ContentBlock is a block of the content of a message.
+type ContentBlock struct {
+	// Type is "text", "tool_use" or "tool_result".
+	Type string `json:"type"`
+	// Text is the text of a text block.
+	Text string `json:"text,omitempty"`
+	// ID is the id of a tool use block.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the tool of a tool use block.
+	Name string `json:"name,omitempty"`
+	// Input is the input to the tool of a tool use block.
+	Input json.RawMessage `json:"input,omitempty"`
+	// ToolUseID is the id of the tool use of a tool result block.
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	// Content is the result of a tool result block.
+	Content string `json:"content,omitempty"`
+}
+
+// Tool is a tool the model can use.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+// ToolChoice decides which tool the model uses.
+type ToolChoice struct {
+	// Type is "auto", "any", "tool" or "none".
+	Type string `json:"type"`
+	// Name is the name of the tool to use when Type is "tool".
+	Name string `json:"name,omitempty"`
+}
+
+// Usage is the number of tokens of a request.
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// MessagesResponse is a response to a messages request.
+type MessagesResponse struct {
+	ID         string          `json:"id"`
+	Model      string          `json:"model"`
+	Role       string          `json:"role"`
+	Content    []*ContentBlock `json:"content"`
+	StopReason string          `json:"stop_reason"`
+	Usage      Usage           `json:"usage"`
+}
+
+// streamEvent is an event of a streamed message.
+type streamEvent struct {
+	Type         string            `json:"type"`
+	Index        int               `json:"index"`
+	Message      *MessagesResponse `json:"message"`
+	ContentBlock *ContentBlock     `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *Usage `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateMessage creates a message with the Messages API.
+func (c *Client) CreateMessage(ctx context.Context, r *MessagesRequest) (*MessagesResponse, error) {
+	c.setMessagesDefaults(r)
+
+	payloadBytes, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+	if c.baseURL == "" {
+		c.baseURL = defaultBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/messages", bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorMessage
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return nil, retry.NewStatusError(resp, errResp.Error.Message)
+	}
+
+	if r.Stream {
+		return parseStreamingMessagesResponse(ctx, resp, r)
+	}
+
+	var response MessagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &response, nil
+}
+
+func (c *Client) setMessagesDefaults(r *MessagesRequest) {
+	if r.MaxTokens == 0 {
+		r.MaxTokens = defaultMessagesMaxTokens
+	}
+	switch {
+	// Prefer the model specified in the request.
+	case r.Model != "":
+	// If no model is set in the request, take the one specified in the client.
+	case c.Model != "":
+		r.Model = c.Model
+	// Fallback: use the default model
+	default:
+		r.Model = defaultMessagesModel
+	}
+	r.Stream = r.StreamingFunc != nil
+}
+
+// parseStreamingMessagesResponse builds the message from the server-sent
+// events of the response, sending the text deltas to the streaming func. A
+// stream ending before the message_stop event is an error.
+func parseStreamingMessagesResponse(ctx context.Context, r *http.Response, payload *MessagesRequest) (*MessagesResponse, error) { // nolint:lll,cyclop
+	response := &MessagesResponse{}
+	partialInputs := map[int]*strings.Builder{}
+	stopped := false
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var event streamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode stream payload: %w", err)
+		}
+
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				response = event.Message
+				response.Content = nil
+			}
+		case "content_block_start":
+			if event.ContentBlock == nil || event.Index != len(response.Content) {
+				return nil, fmt.Errorf("%w: content block %d started", ErrUnexpectedStreamEvent, event.Index)
+			}
+			response.Content = append(response.Content, event.ContentBlock)
+			partialInputs[event.Index] = &strings.Builder{}
+		case "content_block_delta":
+			if event.Index >= len(response.Content) {
+				return nil, fmt.Errorf("%w: delta of content block %d", ErrUnexpectedStreamEvent, event.Index)
+			}
+			block := response.Content[event.Index]
+			switch event.Delta.Type {
+			case "text_delta":
+				block.Text += event.Delta.Text
+				if err := payload.StreamingFunc(ctx, []byte(event.Delta.Text)); err != nil {
+					return nil, fmt.Errorf("streaming func returned an error: %w", err)
+				}
+			case "input_json_delta":
+				partialInputs[event.Index].WriteString(event.Delta.PartialJSON)
+			}
+		case "content_block_stop":
+			if input, ok := partialInputs[event.Index]; ok && input.Len() > 0 && event.Index < len(response.Content) {
+				response.Content[event.Index].Input = json.RawMessage(input.String())
+			}
+		case "message_delta":
+			if event.Delta.StopReason != "" {
+				response.StopReason = event.Delta.StopReason
+			}
+			if event.Usage != nil {
+				response.Usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "message_stop":
+			stopped = true
+		case "error":
+			if event.Error != nil {
+				return nil, fmt.Errorf("API returned an error: %s", event.Error.Message) // nolint:goerr113
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if !stopped {
+		return nil, ErrStreamInterrupted
+	}
+
+	return response, nil
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// Cache stores the generations of LLM calls by key.
+type Cache interface {
+	// Get returns the generations stored for the key, and false if there are none.
+	Get(ctx context.Context, key string) ([]*llms.Generation, bool, error)
+	// Put stores the generations for the key.
+	Put(ctx context.Context, key string, generations []*llms.Generation) error
+}
+
+// message is the part of a chat message used in keys.
+type message struct {
+	Type         schema.ChatMessageType `json:"type"`
+	Content      string                 `json:"content"`
+	Name         string                 `json:"name,omitempty"`
+	FunctionCall *schema.FunctionCall   `json:"function_call,omitempty"`
+	ToolCalls    []schema.ToolCall      `json:"tool_calls,omitempty"`
+	ToolCallID   string                 `json:"tool_call_id,omitempty"`
+}
+
+// PromptKey returns the key of a call with the prompt and options.
+func PromptKey(prompt string, options ...llms.CallOption) (string, error) {
+	return hashKey("prompt", prompt, options)
+}
+
+// MessagesKey returns the key of a call with the messages and options.
+func MessagesKey(messages []schema.ChatMessage, options ...llms.CallOption) (string, error) {
+	keyMessages := make([]message, 0, len(messages))
+	for _, m := range messages {
+		km := message{Type: m.GetType(), Content: m.GetContent()}
+		if named, ok := m.(schema.Named); ok {
+			km.Name = named.GetName()
+		}
+		if ai, ok := m.(schema.AIChatMessage); ok {
+			km.FunctionCall = ai.FunctionCall
+			km.ToolCalls = ai.ToolCalls
+		}
+		if tool, ok := m.(schema.ToolChatMessage); ok {
+			km.ToolCallID = tool.ID
+		}
+		keyMessages = append(keyMessages, km)
+	}
+
+	return hashKey("messages", keyMessages, options)
+}
+
+func hashKey(kind string, input any, options []llms.CallOption) (string, error) {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	b, err := json.Marshal(struct {
+		Kind    string           `json:"kind"`
+		Input   any              `json:"input"`
+		Options llms.CallOptions `json:"options"`
+	}{
+		Kind:    kind,
+		Input:   input,
+		Options: opts,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLLM struct {
+	prompts []string
+}
+
+func (l *countingLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	generations, err := l.Generate(ctx, []string{prompt}, options...)
+	if err != nil {
+		return "", err
+	}
+	return generations[0].Text, nil
+}
+
+func (l *countingLLM) Generate(_ context.Context, prompts []string, _ ...llms.CallOption) ([]*llms.Generation, error) {
+	l.prompts = append(l.prompts, prompts...)
+	generations := make([]*llms.Generation, 0, len(prompts))
+	for _, prompt := range prompts {
+		generations = append(generations, &llms.Generation{Text: "answer to " + prompt})
+	}
+	return generations, nil
+}
+
+type countingChatLLM struct {
+	calls int
+}
+
+func (l *countingChatLLM) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	generations, err := l.Generate(ctx, [][]schema.ChatMessage{messages}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return generations[0].Message, nil
+}
+
+// Generate returns n generations for each message set, like openai does with
+// llms.WithN.
+func (l *countingChatLLM) Generate(_ context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	opts := llms.CallOptions{N: 1}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	generations := make([]*llms.Generation, 0, len(messageSets)*opts.N)
+	for _, messages := range messageSets {
+		l.calls++
+		content := "answer to " + messages[len(messages)-1].GetContent()
+		for i := 0; i < opts.N; i++ {
+			generations = append(generations, &llms.Generation{
+				Text: content,
+				Message: &schema.AIChatMessage{
+					Content:   content,
+					ToolCalls: []schema.ToolCall{{ID: "call", FunctionCall: &schema.FunctionCall{Name: "answer"}}},
+				},
+				Usage: llms.NewUsage("test-model", 2, 3),
+			})
+		}
+	}
+	return generations, nil
+}
+
+// hitRecorder records the llm callbacks.
+type hitRecorder struct {
+	callbacks.SimpleHandler
+	outputs []map[string]any
+	prompts [][]string
+}
+
+func (h *hitRecorder) HandleLLMStart(_ context.Context, prompts []string) {
+	h.prompts = append(h.prompts, prompts)
+}
+
+func (h *hitRecorder) HandleLLMEnd(_ context.Context, result llms.LLMResult) {
+	h.outputs = append(h.outputs, result.LLMOutput)
+}
+
+func TestInMemory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := NewInMemory(2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b"} {
+		require.NoError(t, c.Put(ctx, key, []*llms.Generation{{Text: key}}))
+	}
+
+	// Reading a makes b the least recently used entry.
+	generations, ok, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "a", generations[0].Text)
+
+	require.NoError(t, c.Put(ctx, "c", []*llms.Generation{{Text: "c"}}))
+	require.Equal(t, 2, c.Len())
+	_, ok, _ = c.Get(ctx, "b")
+	require.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "a")
+	require.False(t, ok)
+}
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	a, err := PromptKey("hello", llms.WithTemperature(0.5))
+	require.NoError(t, err)
+	b, err := PromptKey("hello", llms.WithTemperature(0.5))
+	require.NoError(t, err)
+	c, err := PromptKey("hello", llms.WithTemperature(0.7))
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+	require.NotEqual(t, a, c)
+
+	m, err := MessagesKey([]schema.ChatMessage{schema.HumanChatMessage{Content: "hello"}})
+	require.NoError(t, err)
+	require.NotEqual(t, a, m)
+}
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &countingLLM{}
+	recorder := &hitRecorder{}
+	cached := NewLLM(llm, NewInMemory(10, 0), WithCallbacksHandler(recorder))
+
+	result, err := cached.Call(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "answer to a", result)
+	require.Empty(t, recorder.outputs)
+
+	var streamed []string
+	generations, err := cached.Generate(ctx, []string{"a", "b"}, llms.WithStreamingFunc(
+		func(_ context.Context, chunk []byte) error {
+			streamed = append(streamed, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, "answer to a", generations[0].Text)
+	require.Equal(t, "answer to b", generations[1].Text)
+	require.Equal(t, []string{"a", "b"}, llm.prompts)
+	require.Equal(t, []string{"answer to a"}, streamed)
+
+	require.Equal(t, [][]string{{"a"}}, recorder.prompts)
+	require.Equal(t, []map[string]any{{"cache_hit": true}}, recorder.outputs)
+
+	// Different options are a different key.
+	_, err = cached.Call(ctx, "a", llms.WithTemperature(0.9))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "a"}, llm.prompts)
+}
+
+func TestChatLLM(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &countingChatLLM{}
+	cached := NewChatLLM(llm, NewInMemory(10, 0))
+	messages := []schema.ChatMessage{schema.HumanChatMessage{Content: "hi"}}
+
+	for i := 0; i < 2; i++ {
+		msg, err := cached.Call(ctx, messages)
+		require.NoError(t, err)
+		require.Equal(t, "answer to hi", msg.Content)
+	}
+	require.Equal(t, 1, llm.calls)
+
+	result, err := NewLanguageModel(cached, NewInMemory(10, 0)).GeneratePrompt(ctx,
+		[]schema.PromptValue{testPromptValue{messages: messages}})
+	require.NoError(t, err)
+	require.Equal(t, "answer to hi", result.Generations[0][0].Text)
+	require.Equal(t, 1, llm.calls)
+}
+
+type testPromptValue struct {
+	messages []schema.ChatMessage
+}
+
+func (v testPromptValue) String() string                 { return v.messages[0].GetContent() }
+func (v testPromptValue) Messages() []schema.ChatMessage { return v.messages }
+
+func TestChatLLMCopies(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &countingChatLLM{}
+	cached := NewChatLLM(llm, NewInMemory(10, 0))
+	messages := []schema.ChatMessage{schema.HumanChatMessage{Content: "hi"}}
+
+	// Changing the result of a miss or a hit does not change the cache.
+	for i := 0; i < 2; i++ {
+		msg, err := cached.Call(ctx, messages)
+		require.NoError(t, err)
+		require.Equal(t, "answer to hi", msg.Content)
+		require.Equal(t, "answer", msg.ToolCalls[0].FunctionCall.Name)
+		msg.Content = "changed"
+		msg.ToolCalls[0].FunctionCall.Name = "changed"
+	}
+	require.Equal(t, 1, llm.calls)
+}
+
+func TestChatLLMWithN(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := &countingChatLLM{}
+	cached := NewChatLLM(llm, NewInMemory(10, 0))
+	messageSets := [][]schema.ChatMessage{
+		{schema.HumanChatMessage{Content: "hi"}},
+		{schema.HumanChatMessage{Content: "bye"}},
+	}
+
+	// Calls with more than one choice per message set skip the cache.
+	for i := 0; i < 2; i++ {
+		generations, err := cached.Generate(ctx, messageSets, llms.WithN(2))
+		require.NoError(t, err)
+		require.Len(t, generations, 4)
+	}
+	require.Equal(t, 4, llm.calls)
+}
@@ -0,0 +1,12 @@
+// Package cache provides caching of LLM responses.
+//
+// LLM, ChatLLM and LanguageModel wrap a model and look up the generations of a
+// call in a Cache before calling the model. The key of a call is a hash of the
+// prompt or messages and the call options that change the response, so calls
+// with the same inputs return the same generations without calling the model.
+//
+// InMemory is a LRU cache with an optional time to live. The sqlite3
+// subpackage provides a persistent cache. Cache hits are reported to the
+// callbacks handler set with WithCallbacksHandler as an LLM start and end, with
+// "cache_hit" set to true in the LLM output.
+package cache
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+)
+
+// InMemory is a LRU Cache with an optional time to live. It is safe for
+// concurrent use.
+type InMemory struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type inMemoryEntry struct {
+	key         string
+	generations []*llms.Generation
+	expires     time.Time
+}
+
+var _ Cache = (*InMemory)(nil)
+
+// NewInMemory creates a new in-memory cache keeping up to capacity entries,
+// evicting the least recently used entry first. Entries expire after ttl. A
+// capacity or ttl of zero means no limit.
+func NewInMemory(capacity int, ttl time.Duration) *InMemory {
+	return &InMemory{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the generations stored for the key.
+func (c *InMemory) Get(_ context.Context, key string) ([]*llms.Generation, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry, _ := element.Value.(*inMemoryEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(element)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	return entry.generations, true, nil
+}
+
+// Put stores the generations for the key.
+func (c *InMemory) Put(_ context.Context, key string, generations []*llms.Generation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &inMemoryEntry{key: key, generations: generations}
+	if c.ttl > 0 {
+		entry.expires = c.now().Add(c.ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// not removed yet.
+func (c *InMemory) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *InMemory) remove(element *list.Element) {
+	entry, _ := element.Value.(*inMemoryEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+}
@@ -0,0 +1,398 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const _defaultTokenizerModel = "gpt2"
+
+// ErrUnexpectedGenerations is returned when a model returns a different number
+// of generations than prompts.
+var ErrUnexpectedGenerations = errors.New("unexpected number of generations")
+
+// LLM is an llms.LLM that caches the generations of the wrapped llm.
+type LLM struct {
+	llm   llms.LLM
+	cache Cache
+	opts  options
+}
+
+var (
+	_ llms.LLM           = (*LLM)(nil)
+	_ llms.LanguageModel = (*LLM)(nil)
+)
+
+// NewLLM wraps the llm with the cache.
+func NewLLM(llm llms.LLM, cache Cache, opts ...Option) *LLM {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &LLM{llm: llm, cache: cache, opts: o}
+}
+
+// Call returns the cached generation for the prompt, or calls the wrapped llm.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	generations, err := l.Generate(ctx, []string{prompt}, options...)
+	if err != nil {
+		return "", err
+	}
+	if len(generations) == 0 {
+		return "", ErrUnexpectedGenerations
+	}
+
+	return generations[0].Text, nil
+}
+
+// Generate returns the cached generations of the prompts, and calls the wrapped
+// llm with the prompts that are not cached. Calls asking for more than one
+// choice per prompt with llms.WithN are not cached.
+func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	if !cacheable(options) {
+		return l.llm.Generate(ctx, prompts, options...)
+	}
+
+	keys := make([]string, len(prompts))
+	for i, prompt := range prompts {
+		key, err := PromptKey(prompt, options...)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	return generateCached(ctx, l.cache, l.opts, keys, prompts, options,
+		func(ctx context.Context, missed []int) ([]*llms.Generation, error) {
+			missedPrompts := make([]string, 0, len(missed))
+			for _, i := range missed {
+				missedPrompts = append(missedPrompts, prompts[i])
+			}
+			return l.llm.Generate(ctx, missedPrompts, options...)
+		})
+}
+
+// GeneratePrompt generates the prompts through the cache.
+func (l *LLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GeneratePrompt(ctx, l, prompts, options...)
+}
+
+// GetNumTokens uses the wrapped llm if it is a language model.
+func (l *LLM) GetNumTokens(text string) int {
+	return getNumTokens(l.llm, text)
+}
+
+// ChatLLM is an llms.ChatLLM that caches the generations of the wrapped chat llm.
+type ChatLLM struct {
+	llm   llms.ChatLLM
+	cache Cache
+	opts  options
+}
+
+var (
+	_ llms.ChatLLM       = (*ChatLLM)(nil)
+	_ llms.LanguageModel = (*ChatLLM)(nil)
+)
+
+// NewChatLLM wraps the chat llm with the cache.
+func NewChatLLM(llm llms.ChatLLM, cache Cache, opts ...Option) *ChatLLM {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &ChatLLM{llm: llm, cache: cache, opts: o}
+}
+
+// Call returns the cached message for the messages, or calls the wrapped chat llm.
+func (l *ChatLLM) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	generations, err := l.Generate(ctx, [][]schema.ChatMessage{messages}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(generations) == 0 {
+		return nil, ErrUnexpectedGenerations
+	}
+
+	if generations[0].Message != nil {
+		return generations[0].Message, nil
+	}
+	return &schema.AIChatMessage{Content: generations[0].Text}, nil
+}
+
+// Generate returns the cached generations of the message sets, and calls the
+// wrapped chat llm with the message sets that are not cached. Calls asking for
+// more than one choice per message set with llms.WithN are not cached.
+func (l *ChatLLM) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	if !cacheable(options) {
+		return l.llm.Generate(ctx, messageSets, options...)
+	}
+
+	keys := make([]string, len(messageSets))
+	texts := make([]string, len(messageSets))
+	for i, messages := range messageSets {
+		key, err := MessagesKey(messages, options...)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+		texts[i] = messagesText(messages)
+	}
+
+	return generateCached(ctx, l.cache, l.opts, keys, texts, options,
+		func(ctx context.Context, missed []int) ([]*llms.Generation, error) {
+			missedSets := make([][]schema.ChatMessage, 0, len(missed))
+			for _, i := range missed {
+				missedSets = append(missedSets, messageSets[i])
+			}
+			return l.llm.Generate(ctx, missedSets, options...)
+		})
+}
+
+// GeneratePrompt generates the prompts through the cache.
+func (l *ChatLLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GenerateChatPrompt(ctx, l, prompts, options...)
+}
+
+// GetNumTokens uses the wrapped chat llm if it is a language model.
+func (l *ChatLLM) GetNumTokens(text string) int {
+	return getNumTokens(l.llm, text)
+}
+
+// LanguageModel is an llms.LanguageModel that caches the generations of the
+// wrapped language model. Only calls with a single prompt are cached; calls
+// with more prompts always go to the wrapped language model.
+type LanguageModel struct {
+	lm    llms.LanguageModel
+	cache Cache
+	opts  options
+}
+
+var _ llms.LanguageModel = (*LanguageModel)(nil)
+
+// NewLanguageModel wraps the language model with the cache.
+func NewLanguageModel(lm llms.LanguageModel, cache Cache, opts ...Option) *LanguageModel {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &LanguageModel{lm: lm, cache: cache, opts: o}
+}
+
+// GeneratePrompt returns the cached result of the prompt, or calls the wrapped
+// language model. Cached results have no usage.
+func (l *LanguageModel) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	if len(prompts) != 1 {
+		return l.lm.GeneratePrompt(ctx, prompts, options...)
+	}
+
+	key, err := MessagesKey(prompts[0].Messages(), options...)
+	if err != nil {
+		return llms.LLMResult{}, err
+	}
+
+	generations, ok, err := l.cache.Get(ctx, key)
+	if err != nil {
+		return llms.LLMResult{}, err
+	}
+	if ok {
+		generations = copyGenerations(generations)
+		reportHit(ctx, l.opts, []string{prompts[0].String()}, generations)
+		return llms.LLMResult{Generations: [][]*llms.Generation{generations}}, nil
+	}
+
+	result, err := l.lm.GeneratePrompt(ctx, prompts, options...)
+	if err != nil {
+		return result, err
+	}
+	if len(result.Generations) == 1 {
+		if err := l.cache.Put(ctx, key, copyGenerations(result.Generations[0])); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// GetNumTokens returns the number of tokens of the wrapped language model.
+func (l *LanguageModel) GetNumTokens(text string) int {
+	return l.lm.GetNumTokens(text)
+}
+
+// generateCached returns the generations for the keys, looking them up in the
+// cache first and calling generate with the indexes of the keys that missed.
+func generateCached(
+	ctx context.Context,
+	cache Cache,
+	opts options,
+	keys []string,
+	texts []string,
+	options []llms.CallOption,
+	generate func(ctx context.Context, missed []int) ([]*llms.Generation, error),
+) ([]*llms.Generation, error) {
+	generations := make([]*llms.Generation, len(keys))
+	missed := make([]int, 0, len(keys))
+	hits := make([]*llms.Generation, 0, len(keys))
+	hitTexts := make([]string, 0, len(keys))
+	for i, key := range keys {
+		cached, ok, err := cache.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || len(cached) == 0 {
+			missed = append(missed, i)
+			continue
+		}
+		hit := copyGenerations(cached[:1])[0]
+		generations[i] = hit
+		hits = append(hits, hit)
+		hitTexts = append(hitTexts, texts[i])
+	}
+
+	if len(hits) > 0 {
+		reportHit(ctx, opts, hitTexts, hits)
+		if err := streamHits(ctx, options, hits); err != nil {
+			return nil, err
+		}
+	}
+	if len(missed) == 0 {
+		return generations, nil
+	}
+
+	generated, err := generate(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+	if len(generated) != len(missed) {
+		return nil, ErrUnexpectedGenerations
+	}
+
+	for j, i := range missed {
+		generations[i] = generated[j]
+		if err := cache.Put(ctx, keys[i], copyGenerations(generated[j:j+1])); err != nil {
+			return nil, err
+		}
+	}
+
+	return generations, nil
+}
+
+// reportHit reports cache hits to the callbacks handler as an llm call. The
+// result has no usage, as no tokens were spent on it.
+func reportHit(ctx context.Context, opts options, prompts []string, generations []*llms.Generation) {
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, opts.callbacksHandler)
+	if handler == nil {
+		return
+	}
+
+	handler.HandleLLMStart(ctx, prompts)
+	handler.HandleLLMEnd(ctx, llms.LLMResult{
+		Generations: [][]*llms.Generation{generations},
+		LLMOutput:   map[string]any{"cache_hit": true},
+	})
+}
+
+// streamHits sends the text of cached generations to the streaming func of the
+// call, if any, as one chunk each.
+func streamHits(ctx context.Context, options []llms.CallOption, generations []*llms.Generation) error {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.StreamingFunc == nil {
+		return nil
+	}
+
+	for _, generation := range generations {
+		if err := opts.StreamingFunc(ctx, []byte(generation.Text)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyGenerations returns copies of the generations without their usage, so
+// that callers changing the generations they got do not change the cache, and
+// cache hits report no tokens spent.
+func copyGenerations(generations []*llms.Generation) []*llms.Generation {
+	copies := make([]*llms.Generation, len(generations))
+	for i, g := range generations {
+		if g == nil {
+			continue
+		}
+		c := *g
+		c.Usage = nil
+		if g.Message != nil {
+			c.Message = copyMessage(g.Message)
+		}
+		if g.GenerationInfo != nil {
+			c.GenerationInfo = make(map[string]any, len(g.GenerationInfo))
+			for k, v := range g.GenerationInfo {
+				c.GenerationInfo[k] = v
+			}
+		}
+		copies[i] = &c
+	}
+	return copies
+}
+
+// copyMessage returns a copy of the message, including its function and tool
+// calls.
+func copyMessage(m *schema.AIChatMessage) *schema.AIChatMessage {
+	message := *m
+	message.FunctionCall = copyFunctionCall(m.FunctionCall)
+	if m.ToolCalls != nil {
+		message.ToolCalls = make([]schema.ToolCall, len(m.ToolCalls))
+		for i, call := range m.ToolCalls {
+			call.FunctionCall = copyFunctionCall(call.FunctionCall)
+			message.ToolCalls[i] = call
+		}
+	}
+	return &message
+}
+
+func copyFunctionCall(call *schema.FunctionCall) *schema.FunctionCall {
+	if call == nil {
+		return nil
+	}
+	c := *call
+	return &c
+}
+
+// cacheable reports whether a call with the options can be cached. Calls asking
+// for more than one choice per prompt are not, as their generations can not be
+// matched to the prompts.
+func cacheable(options []llms.CallOption) bool {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts.N <= 1
+}
+
+func messagesText(messages []schema.ChatMessage) string {
+	var sb strings.Builder
+	for i, m := range messages {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(string(m.GetType()) + ": " + m.GetContent())
+	}
+	return sb.String()
+}
+
+func getNumTokens(model any, text string) int {
+	if lm, ok := model.(llms.LanguageModel); ok {
+		return lm.GetNumTokens(text)
+	}
+	return llms.CountTokens(_defaultTokenizerModel, text)
+}
@@ -0,0 +1,17 @@
+package cache
+
+import "github.com/aresa7796/langchaingo/callbacks"
+
+type options struct {
+	callbacksHandler callbacks.Handler
+}
+
+// Option is an option for the cached models.
+type Option func(*options)
+
+// WithCallbacksHandler sets the callbacks handler cache hits are reported to.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbacksHandler = handler
+	}
+}
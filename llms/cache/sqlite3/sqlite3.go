@@ -0,0 +1,123 @@
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/cache"
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
+)
+
+const (
+	_driverName       = "sqlite3"
+	_defaultTableName = "llm_cache"
+)
+
+var _ cache.Cache = (*Cache)(nil)
+
+// Cache is a cache storing generations in a SQLite3 database.
+type Cache struct {
+	db        *sql.DB
+	tableName string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// Option is an option for the SQLite3 cache.
+type Option func(*Cache)
+
+// WithTTL sets how long entries are valid. Zero, the default, means forever.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Cache) {
+		c.ttl = ttl
+	}
+}
+
+// WithTableName sets the name of the table entries are stored in.
+// The default is "llm_cache".
+func WithTableName(name string) Option {
+	return func(c *Cache) {
+		c.tableName = name
+	}
+}
+
+// New creates a SQLite3 cache and the table for it if it does not exist.
+// The dsn is the data source name (e.g. file:cache.sqlite).
+func New(dsn string, opts ...Option) (*Cache, error) {
+	db, err := sql.Open(_driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	c := &Cache{
+		db:        db,
+		tableName: _defaultTableName,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS ` + c.tableName + ` (
+		key TEXT PRIMARY KEY,
+		generations TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	)`)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Get returns the generations stored for the key. Expired entries are deleted.
+func (c *Cache) Get(ctx context.Context, key string) ([]*llms.Generation, bool, error) {
+	var raw string
+	var createdAt int64
+	err := c.db.QueryRowContext(ctx,
+		`SELECT generations, created_at FROM `+c.tableName+` WHERE key = ?`, key,
+	).Scan(&raw, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if c.ttl > 0 && c.now().Sub(time.Unix(0, createdAt)) > c.ttl {
+		_, err := c.db.ExecContext(ctx, `DELETE FROM `+c.tableName+` WHERE key = ?`, key)
+		return nil, false, err
+	}
+
+	var generations []*llms.Generation
+	if err := json.Unmarshal([]byte(raw), &generations); err != nil {
+		return nil, false, err
+	}
+
+	return generations, true, nil
+}
+
+// Put stores the generations for the key, replacing any existing entry.
+func (c *Cache) Put(ctx context.Context, key string, generations []*llms.Generation) error {
+	raw, err := json.Marshal(generations)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.db.ExecContext(ctx,
+		`INSERT OR REPLACE INTO `+c.tableName+` (key, generations, created_at) VALUES (?, ?, ?)`,
+		key, string(raw), c.now().UnixNano(),
+	)
+	return err
+}
+
+// Close closes the database of the cache.
+func (c *Cache) Close() error {
+	return c.db.Close()
+}
@@ -0,0 +1,54 @@
+package sqlite3
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dsn := "file:" + filepath.Join(t.TempDir(), "cache.sqlite")
+	c, err := New(dsn, WithTTL(time.Hour))
+	require.NoError(t, err)
+	defer c.Close()
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	_, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	generations := []*llms.Generation{{
+		Text:           "hello",
+		Message:        &schema.AIChatMessage{Content: "hello"},
+		GenerationInfo: map[string]any{"finish_reason": "stop"},
+	}}
+	require.NoError(t, c.Put(ctx, "key", generations))
+
+	cached, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, generations, cached)
+
+	// Entries are kept across connections.
+	reopened, err := New(dsn, WithTTL(time.Hour))
+	require.NoError(t, err)
+	defer reopened.Close()
+	_, ok, err = reopened.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	_, ok, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -3,11 +3,13 @@ package cohere
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/llms/cohere/internal/cohereclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
 	"github.com/aresa7796/langchaingo/schema"
 )
 
@@ -40,32 +42,40 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 }
 
 func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, prompts)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
 	}
 
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 
 	generations := make([]*llms.Generation, 0, len(prompts))
 
 	for _, prompt := range prompts {
 		result, err := o.client.CreateGeneration(ctx, &cohereclient.GenerationRequest{
-			Prompt: prompt,
+			Prompt:        prompt,
+			StreamingFunc: opts.StreamingFunc,
 		})
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 
 		generations = append(generations, &llms.Generation{
-			Text: result.Text,
+			Text:  result.Text,
+			Usage: llms.NewUsage(result.Model, result.InputTokens, result.OutputTokens),
 		})
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 
 	return generations, nil
@@ -92,9 +102,10 @@ func New(opts ...Option) (*LLM, error) {
 
 func newClient(opts ...Option) (*cohereclient.Client, error) {
 	options := &options{
-		token:   os.Getenv(tokenEnvVarName),
-		baseURL: os.Getenv(baseURLEnvVarName),
-		model:   os.Getenv(modelEnvVarName),
+		token:      os.Getenv(tokenEnvVarName),
+		baseURL:    os.Getenv(baseURLEnvVarName),
+		model:      os.Getenv(modelEnvVarName),
+		httpClient: http.DefaultClient,
 	}
 
 	for _, opt := range opts {
@@ -105,5 +116,10 @@ func newClient(opts ...Option) (*cohereclient.Client, error) {
 		return nil, ErrMissingToken
 	}
 
-	return cohereclient.New(options.token, options.baseURL, options.model)
+	if options.retryPolicy != nil {
+		options.httpClient = retry.NewDoer(options.httpClient, *options.retryPolicy)
+	}
+
+	return cohereclient.New(options.token, options.baseURL, options.model,
+		cohereclient.WithHTTPClient(options.httpClient))
 }
@@ -1,5 +1,10 @@
 package cohere
 
+import (
+	"github.com/aresa7796/langchaingo/llms/cohere/internal/cohereclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
 const (
 	tokenEnvVarName   = "COHERE_API_KEY"  //nolint:gosec
 	modelEnvVarName   = "COHERE_MODEL"    //nolint:gosec
@@ -7,9 +12,11 @@ const (
 )
 
 type options struct {
-	token   string
-	model   string
-	baseURL string
+	token       string
+	model       string
+	baseURL     string
+	httpClient  cohereclient.Doer
+	retryPolicy *retry.Policy
 }
 
 type Option func(*options)
@@ -38,3 +45,20 @@ func WithBaseURL(baseURL string) Option {
 		opts.baseURL = baseURL
 	}
 }
+
+// WithHTTPClient allows setting a custom HTTP client. If not set, the default value
+// is http.DefaultClient.
+func WithHTTPClient(client cohereclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry retries the generate requests Cohere answers with a 429 for
+// exceeding the rate limit of the API key, or with a server error. See
+// retry.Policy for the backoff and its defaults.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
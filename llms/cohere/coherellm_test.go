@@ -0,0 +1,139 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/cohere/internal/cohereclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/generate", r.URL.Path)
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		if req["stream"] != true {
+			_, _ = w.Write([]byte(`{"generations":[{"text":"Hello world"}],
+				"meta":{"billed_units":{"input_tokens":2,"output_tokens":3}}}`))
+			return
+		}
+		for _, line := range []string{
+			`{"text":"Hello","is_finished":false}`,
+			`{"text":" world","is_finished":false}`,
+			`{"is_finished":true,"finish_reason":"COMPLETE","response":{"generations":[{"text":"Hello world"}]}}`,
+		} {
+			fmt.Fprintln(w, line)
+		}
+	}))
+	defer server.Close()
+
+	llm, err := New(WithToken("test"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	var chunks []string
+	result, err := llm.Call(context.Background(), "Say hello", llms.WithStreamingFunc(
+		func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", result)
+	require.Equal(t, []string{"Hello", " world"}, chunks)
+
+	generations, err := llm.Generate(context.Background(), []string{"Say hello"})
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", generations[0].Text)
+	require.Equal(t, 5, generations[0].Usage.TotalTokens)
+}
+
+func TestLLMStreamingErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		lines    []string
+		expected error
+	}{
+		{
+			name: "error finish reason",
+			lines: []string{
+				`{"text":"Hello","is_finished":false}`,
+				`{"is_finished":true,"finish_reason":"ERROR_TOXIC"}`,
+			},
+			expected: cohereclient.ErrGenerationFailed,
+		},
+		{
+			name:     "interrupted",
+			lines:    []string{`{"text":"Hello","is_finished":false}`},
+			expected: cohereclient.ErrStreamInterrupted,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for _, line := range tc.lines {
+					fmt.Fprintln(w, line)
+				}
+			}))
+			defer server.Close()
+
+			llm, err := New(WithToken("test"), WithBaseURL(server.URL))
+			require.NoError(t, err)
+
+			_, err = llm.Call(context.Background(), "Say hello", llms.WithStreamingFunc(
+				func(context.Context, []byte) error { return nil }))
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+// countingDoer counts the requests sent through it.
+type countingDoer struct {
+	requests atomic.Int32
+}
+
+func (d *countingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.requests.Add(1)
+	return http.DefaultClient.Do(req)
+}
+
+func TestLLMRetryWithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"generations":[{"text":"Hello world"}]}`))
+	}))
+	defer server.Close()
+
+	doer := &countingDoer{}
+	llm, err := New(WithToken("test"), WithBaseURL(server.URL), WithHTTPClient(doer),
+		WithRetry(retry.Policy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	require.NoError(t, err)
+
+	result, err := llm.Call(context.Background(), "Say hello")
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", result)
+	// Both attempts went through the client of the caller.
+	require.Equal(t, int32(2), doer.requests.Load())
+}
@@ -1,6 +1,7 @@
 package cohereclient
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,12 +10,24 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/aresa7796/langchaingo/llms/retry"
 	"github.com/cohere-ai/tokenizer"
 )
 
 var (
 	ErrEmptyResponse = errors.New("empty response")
 	ErrModelNotFound = errors.New("model not found")
+	// ErrGenerationFailed is returned when a streamed generation finishes with
+	// an error finish reason, like ERROR or ERROR_TOXIC.
+	ErrGenerationFailed = errors.New("generation failed")
+	// ErrStreamInterrupted is returned when a stream ends before the generation
+	// is finished.
+	ErrStreamInterrupted = errors.New("stream ended before the generation finished")
+)
+
+const (
+	finishReasonComplete  = "COMPLETE"
+	finishReasonMaxTokens = "MAX_TOKENS"
 )
 
 type Client struct {
@@ -66,15 +79,34 @@ func New(token string, baseURL string, model string, opts ...Option) (*Client, e
 
 type GenerationRequest struct {
 	Prompt string `json:"prompt"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 }
 
 type Generation struct {
 	Text string `json:"text"`
+	// Model is the model that generated the text.
+	Model string `json:"model"`
+	// InputTokens and OutputTokens are the billed tokens of the request.
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
 }
 
 type generateRequestPayload struct {
 	Prompt string `json:"prompt"`
 	Model  string `json:"model"`
+	Stream bool   `json:"stream,omitempty"`
+}
+
+// streamedGeneration is a line of a streamed generation. The last line has
+// is_finished set and holds the whole response.
+type streamedGeneration struct {
+	Text         string                   `json:"text"`
+	IsFinished   bool                     `json:"is_finished"`
+	FinishReason string                   `json:"finish_reason"`
+	Response     *generateResponsePayload `json:"response"`
 }
 
 type generateResponsePayload struct {
@@ -84,6 +116,12 @@ type generateResponsePayload struct {
 		ID   string `json:"id,omitempty"`
 		Text string `json:"text,omitempty"`
 	} `json:"generations,omitempty"`
+	Meta struct {
+		BilledUnits struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"billed_units"`
+	} `json:"meta"`
 }
 
 func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*Generation, error) {
@@ -94,6 +132,7 @@ func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*G
 	payload := generateRequestPayload{
 		Prompt: r.Prompt,
 		Model:  c.model,
+		Stream: r.StreamingFunc != nil,
 	}
 
 	payloadBytes, err := json.Marshal(&payload)
@@ -120,6 +159,15 @@ func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*G
 	}
 	defer res.Body.Close()
 
+	if payload.Stream && res.StatusCode == http.StatusOK {
+		generation, err := parseStreamingGeneration(ctx, res, r)
+		if err != nil {
+			return nil, err
+		}
+		generation.Model = c.model
+		return generation, nil
+	}
+
 	var response generateResponsePayload
 	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
 		return nil, fmt.Errorf("parse response: %w", err)
@@ -129,15 +177,80 @@ func (c *Client) CreateGeneration(ctx context.Context, r *GenerationRequest) (*G
 		if strings.HasPrefix(response.Message, "model not found") {
 			return nil, ErrModelNotFound
 		}
+		if res.StatusCode != http.StatusOK {
+			return nil, retry.NewStatusError(res, response.Message)
+		}
 		return nil, ErrEmptyResponse
 	}
 
+	return &Generation{
+		Text:         response.Generations[0].Text,
+		Model:        c.model,
+		InputTokens:  response.Meta.BilledUnits.InputTokens,
+		OutputTokens: response.Meta.BilledUnits.OutputTokens,
+	}, nil
+}
+
+// parseStreamingGeneration reads the lines of a streamed generation, sending
+// the text of each line to the streaming func of the request. Generations that
+// finish for another reason than being complete or reaching the max tokens, or
+// that never finish, are errors.
+func parseStreamingGeneration(ctx context.Context, res *http.Response, r *GenerationRequest) (*Generation, error) {
 	var generation Generation
-	generation.Text = response.Generations[0].Text
+	var text strings.Builder
+	finished := false
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "data:"))
+		if line == "" {
+			continue
+		}
 
+		var chunk streamedGeneration
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return nil, fmt.Errorf("parse stream payload: %w", err)
+		}
+		if chunk.IsFinished {
+			if err := checkFinishReason(chunk.FinishReason); err != nil {
+				return nil, err
+			}
+			finished = true
+			if chunk.Response != nil {
+				generation.InputTokens = chunk.Response.Meta.BilledUnits.InputTokens
+				generation.OutputTokens = chunk.Response.Meta.BilledUnits.OutputTokens
+			}
+			break
+		}
+		text.WriteString(chunk.Text)
+		if err := r.StreamingFunc(ctx, []byte(chunk.Text)); err != nil {
+			return nil, fmt.Errorf("streaming func returned an error: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if !finished {
+		return nil, ErrStreamInterrupted
+	}
+	if text.Len() == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	generation.Text = text.String()
 	return &generation, nil
 }
 
+// checkFinishReason returns an error for the finish reasons of failed
+// generations. An empty finish reason is taken as complete.
+func checkFinishReason(reason string) error {
+	switch reason {
+	case "", finishReasonComplete, finishReasonMaxTokens:
+		return nil
+	default:
+		return fmt.Errorf("%w: finish reason %s", ErrGenerationFailed, reason)
+	}
+}
+
 func (c *Client) GetNumTokens(text string) int {
 	encoded, _ := c.encoder.Encode(text)
 	return len(encoded)
@@ -8,6 +8,8 @@
 // 3. OpenAI:            llms/openai/
 // 4. Vertex AI:         llms/vertexai/
 // 5. Cohere:            llms/cohere/
+// 6. Ollama:            llms/ollama/
+// 7. llama.cpp server:  llms/llamacpp/
 //
 // Each subpackage includes provider-specific LLM implementations and helper files for communication
 // with supported LLM providers. The internal directories within these subpackages contain provider-specific
@@ -16,4 +18,22 @@
 // The `llms.go` file contains the types and interfaces for interacting with different LLMs.
 //
 // The `options.go` file provides various options and functions to configure the LLMs.
+//
+// # Streaming
+//
+// All providers accept WithStreamingFunc. These stream the response as it is
+// generated: OpenAI, Anthropic, Cohere, ERNIE, Ollama, the llama.cpp server,
+// Hugging Face models served by text-generation-inference, and local binaries,
+// whose stdout is read as it is written. Vertex AI PaLM does not stream, and
+// its responses are sent to the streaming func as a single chunk once they are
+// complete.
+//
+// # Token usage
+//
+// Generations carry the token usage of their call in Generation.Usage, and
+// LLMResult.Usage sums it up. OpenAI, Anthropic chat, Cohere, ERNIE, Ollama and
+// the llama.cpp server report the usage of the API. Vertex AI PaLM does not, so
+// its usage is estimated with GetNumTokens. Hugging Face, local binaries and
+// the legacy Anthropic completions API report no usage. callbacks.CostHandler
+// aggregates the usage and its cost per model.
 package llms
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/llms/ernie/internal/ernieclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
 	"github.com/aresa7796/langchaingo/schema"
 )
 
@@ -17,8 +20,9 @@ var (
 )
 
 type LLM struct {
-	client *ernieclient.Client
-	model  ModelName
+	CallbacksHandler callbacks.Handler
+	client           *ernieclient.Client
+	model            ModelName
 }
 
 var (
@@ -37,8 +41,9 @@ func New(opts ...Option) (*LLM, error) {
 
 func newClient(opts ...Option) (*ernieclient.Client, error) {
 	options := &options{
-		apiKey:    os.Getenv(ernieAPIKey),
-		secretKey: os.Getenv(ernieSecretKey),
+		apiKey:     os.Getenv(ernieAPIKey),
+		secretKey:  os.Getenv(ernieSecretKey),
+		httpClient: http.DefaultClient,
 	}
 
 	for _, opt := range opts {
@@ -54,9 +59,15 @@ export ERNIE_SECRET_KEY={Secret Key}
 doc: https://cloud.baidu.com/doc/WENXINWORKSHOP/s/flfmc9do2`, ernieclient.ErrNotSetAuth)
 	}
 
+	if options.retryPolicy != nil {
+		options.httpClient = retry.NewDoer(options.httpClient, *options.retryPolicy)
+	}
+
 	return ernieclient.New(
 		ernieclient.WithAccessToken(options.accessToken),
-		ernieclient.WithAKSK(options.apiKey, options.secretKey))
+		ernieclient.WithAKSK(options.apiKey, options.secretKey),
+		ernieclient.WithHTTPClient(options.httpClient),
+	)
 }
 
 // GeneratePrompt implements llms.LanguageModel.
@@ -89,10 +100,17 @@ func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 
 // Generate implements llms.LLM.
 func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, l.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
+	}
+
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 
 	generations := make([]*llms.Generation, 0, len(prompts))
 	for _, prompt := range prompts {
@@ -105,13 +123,25 @@ func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 			Stream:        opts.StreamingFunc != nil,
 		})
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 		generations = append(generations, &llms.Generation{
 			Text: result.Result,
+			Usage: &llms.Usage{
+				Model:            string(l.getModelName(opts)),
+				PromptTokens:     result.Usage.PromptTokens,
+				CompletionTokens: result.Usage.CompletionTokens,
+				TotalTokens:      result.Usage.TotalTokens,
+			},
 		})
 	}
 
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
 	return generations, nil
 }
 
@@ -138,14 +168,22 @@ func (l *LLM) CreateEmbedding(ctx context.Context, texts []string) ([][]float64,
 	return emb, nil
 }
 
-func (l *LLM) getModelPath(opts llms.CallOptions) ernieclient.ModelPath {
+// getModelName returns the model of the call, ERNIE-Bot if none is set.
+func (l *LLM) getModelName(opts llms.CallOptions) ModelName {
 	model := l.model
 
 	if model == "" {
 		model = ModelName(opts.Model)
 	}
+	if model == "" {
+		model = ModelNameERNIEBot
+	}
 
-	switch model {
+	return model
+}
+
+func (l *LLM) getModelPath(opts llms.CallOptions) ernieclient.ModelPath {
+	switch l.getModelName(opts) {
 	case ModelNameERNIEBot:
 		return "completions"
 	case ModelNameERNIEBotTurbo:
@@ -1,5 +1,10 @@
 package ernie
 
+import (
+	"github.com/aresa7796/langchaingo/llms/ernie/internal/ernieclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
 const (
 	ernieAPIKey    = "ERNIE_API_KEY"    //nolint:gosec
 	ernieSecretKey = "ERNIE_SECRET_KEY" //nolint:gosec
@@ -21,6 +26,8 @@ type options struct {
 	secretKey   string
 	accessToken string
 	modelName   ModelName
+	httpClient  ernieclient.Doer
+	retryPolicy *retry.Policy
 }
 
 type Option func(*options)
@@ -54,3 +61,20 @@ func WithModelName(modelName ModelName) Option {
 		opts.modelName = modelName
 	}
 }
+
+// WithHTTPClient allows setting a custom HTTP client. If not set, the default value
+// is http.DefaultClient.
+func WithHTTPClient(client ernieclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry retries the requests to the Qianfan platform, including the ones
+// fetching the access token, that fail with a rate limit, a server error or a
+// network error. See retry.Policy for the backoff and its defaults.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
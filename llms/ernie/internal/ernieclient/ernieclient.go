@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
 )
 
 var (
@@ -202,7 +204,7 @@ func (c *Client) CreateCompletion(ctx context.Context, modelPath ModelPath, r *C
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrCompletionCode, resp.StatusCode)
+		return nil, statusError(resp, ErrCompletionCode)
 	}
 
 	if r.Stream {
@@ -238,7 +240,7 @@ func (c *Client) CreateEmbedding(ctx context.Context, texts []string) (*Embeddin
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrEmbeddingCode, resp.StatusCode)
+		return nil, statusError(resp, ErrEmbeddingCode)
 	}
 
 	var response EmbeddingResponse
@@ -263,7 +265,7 @@ func (c *Client) getAccessToken(ctx context.Context) (*authResponse, error) {
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d", ErrAccessTokenCode, resp.StatusCode)
+		return nil, statusError(resp, ErrAccessTokenCode)
 	}
 
 	var response authResponse
@@ -315,3 +317,11 @@ func parseStreamingCompletionResponse(ctx context.Context, resp *http.Response,
 	lastResponse.Usage.CompletionTokens = lastResponse.Usage.TotalTokens - lastResponse.Usage.PromptTokens
 	return lastResponse, nil
 }
+
+// statusError returns the status error of the response, wrapping the error of
+// the API that was called.
+func statusError(resp *http.Response, err error) error {
+	statusErr := retry.NewStatusError(resp, "")
+	statusErr.Err = err
+	return statusErr
+}
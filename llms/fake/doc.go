@@ -0,0 +1,12 @@
+// Package fake provides deterministic models for testing chains and agents
+// without calling a provider.
+//
+// LLM and ChatLLM replay queued Responses in order. A response can be plain
+// text, a function call, a list of chunks sent to the streaming func of the
+// call, or an error. With WithEcho the models answer with the prompt, or the
+// content of the last message, once the queue is empty. Every call is recorded
+// and can be inspected with Calls.
+//
+// Embedder returns embeddings derived from a hash of the words of a text, so
+// equal texts get equal vectors and texts sharing words get similar vectors.
+package fake
@@ -0,0 +1,71 @@
+package fake
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+)
+
+const _defaultDimensions = 64
+
+// Embedder is a deterministic embeddings.Embedder. Every word of a text adds
+// one to a dimension chosen by the hash of the word, and the vector is
+// normalized to unit length.
+type Embedder struct {
+	dimensions int
+}
+
+var _ embeddings.Embedder = Embedder{}
+
+// NewEmbedder creates a fake embedder with vectors of the given dimensions.
+// Values below one use the default of 64.
+func NewEmbedder(dimensions int) Embedder {
+	if dimensions < 1 {
+		dimensions = _defaultDimensions
+	}
+	return Embedder{dimensions: dimensions}
+}
+
+// EmbedDocuments returns a vector for each text.
+func (e Embedder) EmbedDocuments(_ context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, e.embed(text))
+	}
+	return vectors, nil
+}
+
+// EmbedQuery returns the vector of the text.
+func (e Embedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	return e.embed(text), nil
+}
+
+func (e Embedder) embed(text string) []float64 {
+	dimensions := e.dimensions
+	if dimensions < 1 {
+		dimensions = _defaultDimensions
+	}
+
+	vector := make([]float64, dimensions)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(word))
+		vector[h.Sum64()%uint64(dimensions)]++
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
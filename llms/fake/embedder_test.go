@@ -0,0 +1,29 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/llms/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	e := fake.NewEmbedder(16)
+	vectors, err := e.EmbedDocuments(ctx, []string{"the cat sat", "The cat sat", "stock market news"})
+	require.NoError(t, err)
+	require.Len(t, vectors[0], 16)
+	require.Equal(t, vectors[0], vectors[1])
+
+	query, err := e.EmbedQuery(ctx, "cat")
+	require.NoError(t, err)
+	similar, err := embeddings.CosineSimilarity(query, vectors[0])
+	require.NoError(t, err)
+	other, err := embeddings.CosineSimilarity(query, vectors[2])
+	require.NoError(t, err)
+	require.Greater(t, similar, other)
+}
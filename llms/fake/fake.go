@@ -0,0 +1,291 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// ErrNoResponse is returned when a model is called with no queued responses
+// left and echo mode off.
+var ErrNoResponse = errors.New("no fake response queued")
+
+// Response is a scripted response of a fake model.
+type Response struct {
+	// Text is the content of the response.
+	Text string
+	// FunctionCall is the function call of the response, if any.
+	FunctionCall *schema.FunctionCall
+	// ToolCalls are the tool calls of the response, if any.
+	ToolCalls []schema.ToolCall
+	// Chunks are sent to the streaming func of the call. If empty, Text is sent
+	// as one chunk.
+	Chunks []string
+	// GenerationInfo is the generation info of the response.
+	GenerationInfo map[string]any
+	// Usage is the token usage reported with the response, if any.
+	Usage *llms.Usage
+	// Err is returned instead of the response, if set.
+	Err error
+}
+
+// Call is a recorded call of a fake model.
+type Call struct {
+	// Prompt is the prompt of a call to LLM.
+	Prompt string
+	// Messages are the messages of a call to ChatLLM.
+	Messages []schema.ChatMessage
+	// Options are the options of the call.
+	Options llms.CallOptions
+}
+
+// script holds the queued responses and recorded calls shared by the models.
+type script struct {
+	mu        sync.Mutex
+	responses []Response
+	echo      bool
+	calls     []Call
+}
+
+func newScript(opts []Option) script {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return script{responses: o.responses, echo: o.echo}
+}
+
+// next records the call and returns the next response.
+func (s *script) next(call Call, echo string) (Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+	if len(s.responses) == 0 {
+		if !s.echo {
+			return Response{}, ErrNoResponse
+		}
+		return Response{Text: echo}, nil
+	}
+
+	response := s.responses[0]
+	s.responses = s.responses[1:]
+	return response, response.Err
+}
+
+// respond records the call and returns the next response, streaming it if the
+// call has a streaming func. Streamed chunks are reported to the handler as new
+// tokens.
+func (s *script) respond(ctx context.Context, handler callbacks.Handler, call Call, echo string) (*llms.Generation, error) { //nolint:lll
+	response, err := s.next(call, echo)
+	if err != nil {
+		return nil, err
+	}
+
+	if streamingFunc := callbacks.StreamingFunc(handler, call.Options.StreamingFunc); streamingFunc != nil {
+		chunks := response.Chunks
+		if len(chunks) == 0 && response.Text != "" {
+			chunks = []string{response.Text}
+		}
+		for _, chunk := range chunks {
+			if err := streamingFunc(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	text := response.Text
+	if text == "" && len(response.Chunks) > 0 {
+		text = strings.Join(response.Chunks, "")
+	}
+
+	return &llms.Generation{
+		Text: text,
+		Message: &schema.AIChatMessage{
+			Content:      text,
+			FunctionCall: response.FunctionCall,
+			ToolCalls:    response.ToolCalls,
+		},
+		GenerationInfo: response.GenerationInfo,
+		Usage:          response.Usage,
+	}, nil
+}
+
+// Add queues more responses.
+func (s *script) Add(responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, responses...)
+}
+
+// Calls returns the recorded calls in order.
+func (s *script) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]Call, len(s.calls))
+	copy(calls, s.calls)
+	return calls
+}
+
+// Reset removes the queued responses and the recorded calls.
+func (s *script) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = nil
+	s.calls = nil
+}
+
+// GetNumTokens returns the number of words of the text.
+func (s *script) GetNumTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+func callOptions(options []llms.CallOption) llms.CallOptions {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+// LLM is a fake llms.LLM replaying scripted responses.
+type LLM struct {
+	CallbacksHandler callbacks.Handler
+	script
+}
+
+var (
+	_ llms.LLM           = (*LLM)(nil)
+	_ llms.LanguageModel = (*LLM)(nil)
+)
+
+// NewLLM creates a fake LLM.
+func NewLLM(opts ...Option) *LLM {
+	return &LLM{script: newScript(opts)}
+}
+
+// Call returns the text of the next response.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	generations, err := l.Generate(ctx, []string{prompt}, options...)
+	if err != nil {
+		return "", err
+	}
+	return generations[0].Text, nil
+}
+
+// Generate returns the next response for each prompt.
+func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, l.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
+	}
+
+	opts := callOptions(options)
+	generations := make([]*llms.Generation, 0, len(prompts))
+	for _, prompt := range prompts {
+		generation, err := l.respond(ctx, handler, Call{Prompt: prompt, Options: opts}, prompt)
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		generations = append(generations, generation)
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+// GeneratePrompt returns the next response for each prompt.
+func (l *LLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GeneratePrompt(ctx, l, prompts, options...)
+}
+
+// ChatLLM is a fake llms.ChatLLM replaying scripted responses.
+type ChatLLM struct {
+	CallbacksHandler callbacks.Handler
+	script
+}
+
+var (
+	_ llms.ChatLLM       = (*ChatLLM)(nil)
+	_ llms.LanguageModel = (*ChatLLM)(nil)
+)
+
+// NewChatLLM creates a fake chat LLM.
+func NewChatLLM(opts ...Option) *ChatLLM {
+	return &ChatLLM{script: newScript(opts)}
+}
+
+// Call returns the message of the next response.
+func (l *ChatLLM) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	generations, err := l.Generate(ctx, [][]schema.ChatMessage{messages}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return generations[0].Message, nil
+}
+
+// Generate returns the next response for each set of messages.
+func (l *ChatLLM) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, l.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
+	}
+
+	opts := callOptions(options)
+	generations := make([]*llms.Generation, 0, len(messageSets))
+	for _, messages := range messageSets {
+		generation, err := l.respond(ctx, handler, Call{Messages: messages, Options: opts}, lastContent(messages))
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		generations = append(generations, generation)
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+// GeneratePrompt returns the next response for each prompt.
+func (l *ChatLLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GenerateChatPrompt(ctx, l, prompts, options...)
+}
+
+func getPromptsFromMessageSets(messageSets [][]schema.ChatMessage) []string {
+	prompts := make([]string, 0, len(messageSets))
+	for _, messages := range messageSets {
+		contents := make([]string, 0, len(messages))
+		for _, m := range messages {
+			contents = append(contents, m.GetContent())
+		}
+		prompts = append(prompts, strings.Join(contents, "\n"))
+	}
+	return prompts
+}
+
+func lastContent(messages []schema.ChatMessage) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	return messages[len(messages)-1].GetContent()
+}
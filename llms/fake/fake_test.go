@@ -0,0 +1,78 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/fake"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	llm := fake.NewLLM(fake.WithTexts("Paris"), fake.WithEcho())
+	chain := chains.NewLLMChain(llm, prompts.NewPromptTemplate("Capital of {{.country}}?", []string{"country"}))
+
+	result, err := chains.Run(ctx, chain, "France")
+	require.NoError(t, err)
+	require.Equal(t, "Paris", result)
+
+	result, err = chains.Run(ctx, chain, "Italy")
+	require.NoError(t, err)
+	require.Equal(t, "Capital of Italy?", result)
+
+	calls := llm.Calls()
+	require.Len(t, calls, 2)
+	require.Equal(t, "Capital of France?", calls[0].Prompt)
+
+	_, err = fake.NewLLM().Call(ctx, "hello")
+	require.ErrorIs(t, err, fake.ErrNoResponse)
+}
+
+func TestChatLLM(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	errDown := errors.New("down")
+	llm := fake.NewChatLLM(fake.WithResponses(
+		fake.Response{FunctionCall: &schema.FunctionCall{Name: "search", Arguments: `{"query":"go"}`}},
+		fake.Response{Chunks: []string{"Hello", ", ", "world"}},
+		fake.Response{Err: errDown},
+	))
+	messages := []schema.ChatMessage{schema.HumanChatMessage{Content: "hi"}}
+
+	msg, err := llm.Call(ctx, messages, llms.WithFunctions([]llms.FunctionDefinition{{Name: "search"}}))
+	require.NoError(t, err)
+	require.Equal(t, "search", msg.FunctionCall.Name)
+
+	var chunks []string
+	msg, err = llm.Call(ctx, messages, llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "Hello, world", msg.Content)
+	require.Equal(t, []string{"Hello", ", ", "world"}, chunks)
+
+	_, err = llm.Call(ctx, messages)
+	require.ErrorIs(t, err, errDown)
+
+	calls := llm.Calls()
+	require.Len(t, calls, 3)
+	require.Equal(t, messages, calls[0].Messages)
+	require.Equal(t, "search", calls[0].Options.Functions[0].Name)
+
+	llm.Reset()
+	require.Empty(t, llm.Calls())
+	llm.Add(fake.Response{Text: "again"})
+	msg, err = llm.Call(ctx, messages)
+	require.NoError(t, err)
+	require.Equal(t, "again", msg.Content)
+}
@@ -0,0 +1,33 @@
+package fake
+
+type options struct {
+	responses []Response
+	echo      bool
+}
+
+// Option is an option for the fake models.
+type Option func(*options)
+
+// WithResponses queues the responses the model replays in order.
+func WithResponses(responses ...Response) Option {
+	return func(o *options) {
+		o.responses = append(o.responses, responses...)
+	}
+}
+
+// WithTexts queues text responses the model replays in order.
+func WithTexts(texts ...string) Option {
+	return func(o *options) {
+		for _, text := range texts {
+			o.responses = append(o.responses, Response{Text: text})
+		}
+	}
+}
+
+// WithEcho makes the model answer with its input once the queued responses are
+// used up, instead of returning ErrNoResponse.
+func WithEcho() Option {
+	return func(o *options) {
+		o.echo = true
+	}
+}
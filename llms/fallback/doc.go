@@ -0,0 +1,13 @@
+// Package fallback provides a language model that falls back to other providers
+// when one fails.
+//
+// LanguageModel wraps an ordered list of llms.LanguageModel implementations and
+// tries them in turn. The next model is only tried if the error of the previous
+// one is worth falling back on: by default timeouts, network errors, rate limits
+// and server errors. Other errors, such as invalid requests, and cancellation of
+// the context are returned as is.
+//
+// The name and index of the model that answered are recorded in the
+// GenerationInfo of every generation, under the "provider" and
+// "provider_index" keys.
+package fallback
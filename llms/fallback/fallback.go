@@ -0,0 +1,147 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/ratelimit"
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const (
+	// ProviderKey is the generation info key of the name of the model that answered.
+	ProviderKey = "provider"
+	// ProviderIndexKey is the generation info key of the index of the model that answered.
+	ProviderIndexKey = "provider_index"
+)
+
+var (
+	// ErrNoModels is returned when the fallback language model has no models.
+	ErrNoModels = errors.New("no models to fall back on")
+	// ErrAllModelsFailed is returned, joined with the errors of the models, when
+	// every model failed.
+	ErrAllModelsFailed = errors.New("all models failed")
+)
+
+// LanguageModel is an llms.LanguageModel that tries a list of models in order.
+type LanguageModel struct {
+	models []llms.LanguageModel
+	opts   options
+}
+
+var _ llms.LanguageModel = (*LanguageModel)(nil)
+
+// New creates a fallback language model trying the models in the given order.
+func New(models []llms.LanguageModel, opts ...Option) (*LanguageModel, error) {
+	if len(models) == 0 {
+		return nil, ErrNoModels
+	}
+
+	o := options{shouldFallback: ShouldFallback}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &LanguageModel{models: models, opts: o}, nil
+}
+
+// GeneratePrompt calls the models in order until one answers or fails with an
+// error that is not worth falling back on.
+func (l *LanguageModel) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	errs := make([]error, 0, len(l.models))
+	for i, model := range l.models {
+		result, err := l.generate(ctx, model, prompts, options)
+		if err == nil {
+			return withProvider(result, l.name(i), i), nil
+		}
+
+		if ctx.Err() != nil || !l.opts.shouldFallback(err) {
+			return llms.LLMResult{}, err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", l.name(i), err))
+	}
+
+	return llms.LLMResult{}, fmt.Errorf("%w: %w", ErrAllModelsFailed, errors.Join(errs...))
+}
+
+func (l *LanguageModel) generate(
+	ctx context.Context,
+	model llms.LanguageModel,
+	prompts []schema.PromptValue,
+	options []llms.CallOption,
+) (llms.LLMResult, error) {
+	if l.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.opts.timeout)
+		defer cancel()
+	}
+
+	return model.GeneratePrompt(ctx, prompts, options...)
+}
+
+// GetNumTokens returns the number of tokens of the text for the first model.
+func (l *LanguageModel) GetNumTokens(text string) int {
+	return l.models[0].GetNumTokens(text)
+}
+
+func (l *LanguageModel) name(i int) string {
+	if i < len(l.opts.names) && l.opts.names[i] != "" {
+		return l.opts.names[i]
+	}
+	return fmt.Sprintf("%T", l.models[i])
+}
+
+// withProvider returns a copy of the result with the provider recorded in the
+// generation info of every generation. The generations of the model are not
+// modified as they may be shared, for example by a cache.
+func withProvider(result llms.LLMResult, name string, index int) llms.LLMResult {
+	generations := make([][]*llms.Generation, len(result.Generations))
+	for i, gens := range result.Generations {
+		generations[i] = make([]*llms.Generation, len(gens))
+		for j, gen := range gens {
+			if gen == nil {
+				continue
+			}
+			info := make(map[string]any, len(gen.GenerationInfo)+2)
+			for k, v := range gen.GenerationInfo {
+				info[k] = v
+			}
+			info[ProviderKey] = name
+			info[ProviderIndexKey] = index
+
+			copied := *gen
+			copied.GenerationInfo = info
+			generations[i][j] = &copied
+		}
+	}
+	result.Generations = generations
+
+	return result
+}
+
+// ShouldFallback reports whether the next model is worth trying after the error.
+// Timeouts, network errors, client-side rate limits and status errors of the
+// provider clients that are worth retrying, see retry.IsRetryable, are.
+func ShouldFallback(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ratelimit.ErrRateLimited) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var statusErr *retry.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Retryable()
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package fallback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/chains"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/aresa7796/langchaingo/prompts"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// testModel answers with its text, or fails with its error.
+type testModel struct {
+	text  string
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (m *testModel) GeneratePrompt(ctx context.Context, _ []schema.PromptValue, _ ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	m.calls++
+	if m.delay > 0 {
+		select {
+		case <-time.After(m.delay):
+		case <-ctx.Done():
+			return llms.LLMResult{}, ctx.Err()
+		}
+	}
+	if m.err != nil {
+		return llms.LLMResult{}, m.err
+	}
+	return llms.LLMResult{Generations: [][]*llms.Generation{{{Text: m.text}}}}, nil
+}
+
+func (m *testModel) GetNumTokens(text string) int {
+	return len(text)
+}
+
+func TestLanguageModel(t *testing.T) {
+	t.Parallel()
+
+	down := &testModel{err: &retry.StatusError{StatusCode: 503}}
+	slow := &testModel{text: "slow", delay: time.Second}
+	local := &testModel{text: "local"}
+
+	lm, err := New([]llms.LanguageModel{down, slow, local},
+		WithNames("openai", "anthropic"),
+		WithTimeout(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	chain := chains.NewLLMChain(lm, prompts.NewPromptTemplate("{{.input}}", []string{"input"}))
+	result, err := chains.Run(context.Background(), chain, "hello")
+	require.NoError(t, err)
+	require.Equal(t, "local", result)
+
+	res, err := lm.GeneratePrompt(context.Background(), nil)
+	require.NoError(t, err)
+	info := res.Generations[0][0].GenerationInfo
+	require.Equal(t, "*fallback.testModel", info[ProviderKey])
+	require.Equal(t, 2, info[ProviderIndexKey])
+	require.Equal(t, 2, down.calls)
+}
+
+func TestLanguageModelNoFallback(t *testing.T) {
+	t.Parallel()
+
+	invalid := &testModel{err: &retry.StatusError{StatusCode: 400}}
+	other := &testModel{text: "other"}
+
+	lm, err := New([]llms.LanguageModel{invalid, other})
+	require.NoError(t, err)
+
+	_, err = lm.GeneratePrompt(context.Background(), nil)
+	require.ErrorContains(t, err, "400")
+	require.Equal(t, 0, other.calls)
+}
+
+func TestLanguageModelAllFailed(t *testing.T) {
+	t.Parallel()
+
+	lm, err := New([]llms.LanguageModel{
+		&testModel{err: &retry.StatusError{StatusCode: 429}},
+		&testModel{err: context.DeadlineExceeded},
+	}, WithNames("a", "b"))
+	require.NoError(t, err)
+
+	_, err = lm.GeneratePrompt(context.Background(), nil)
+	require.ErrorIs(t, err, ErrAllModelsFailed)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "a: API returned unexpected status code: 429")
+
+	_, err = New(nil)
+	require.ErrorIs(t, err, ErrNoModels)
+}
+
+func TestShouldFallback(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{&retry.StatusError{StatusCode: 500}, true},
+		{&retry.StatusError{StatusCode: 529}, true},
+		{fmt.Errorf("wrapped: %w", &retry.StatusError{StatusCode: 503}), true},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), true},
+		{&retry.StatusError{StatusCode: 401}, false},
+		{errors.New("API returned unexpected status code: 500"), false},
+		{context.Canceled, false},
+		{errors.New("invalid prompt"), false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, ShouldFallback(tc.err), tc.err.Error())
+	}
+}
@@ -0,0 +1,36 @@
+package fallback
+
+import "time"
+
+type options struct {
+	names          []string
+	timeout        time.Duration
+	shouldFallback func(error) bool
+}
+
+// Option is an option for the fallback language model.
+type Option func(*options)
+
+// WithNames sets the names of the models recorded in the generation info, in
+// the order of the models. Models without a name are named after their type.
+func WithNames(names ...string) Option {
+	return func(o *options) {
+		o.names = names
+	}
+}
+
+// WithTimeout sets the timeout of the call to each model. When a model times
+// out the next one is tried. Zero, the default, means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithShouldFallback sets the function deciding if the next model is tried
+// after an error. The default is ShouldFallback.
+func WithShouldFallback(shouldFallback func(error) bool) Option {
+	return func(o *options) {
+		o.shouldFallback = shouldFallback
+	}
+}
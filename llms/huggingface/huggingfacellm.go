@@ -3,11 +3,13 @@ package huggingface
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/llms/huggingface/internal/huggingfaceclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
 	"github.com/aresa7796/langchaingo/schema"
 )
 
@@ -39,14 +41,17 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 }
 
 func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, prompts)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
 	}
 
 	opts := &llms.CallOptions{Model: defaultModel}
 	for _, opt := range options {
 		opt(opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 	result, err := o.client.RunInference(ctx, &huggingfaceclient.InferenceRequest{
 		Model:             o.client.Model,
 		Prompt:            prompts[0],
@@ -58,8 +63,12 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 		MaxLength:         opts.MaxLength,
 		RepetitionPenalty: opts.RepetitionPenalty,
 		Seed:              opts.Seed,
+		StreamingFunc:     opts.StreamingFunc,
 	})
 	if err != nil {
+		if handler != nil {
+			handler.HandleLLMError(ctx, err)
+		}
 		return nil, err
 	}
 
@@ -67,8 +76,8 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 		{Text: result.Text},
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 	return generations, nil
 }
@@ -83,8 +92,9 @@ func (o *LLM) GetNumTokens(text string) int {
 
 func New(opts ...Option) (*LLM, error) {
 	options := &options{
-		token: os.Getenv(tokenEnvVarName),
-		model: defaultModel,
+		token:      os.Getenv(tokenEnvVarName),
+		model:      defaultModel,
+		httpClient: http.DefaultClient,
 	}
 
 	for _, opt := range opts {
@@ -95,7 +105,12 @@ func New(opts ...Option) (*LLM, error) {
 		return nil, ErrMissingToken
 	}
 
-	c, err := huggingfaceclient.New(options.token, options.model)
+	if options.retryPolicy != nil {
+		options.httpClient = retry.NewDoer(options.httpClient, *options.retryPolicy)
+	}
+
+	c, err := huggingfaceclient.New(options.token, options.model,
+		huggingfaceclient.WithHTTPClient(options.httpClient))
 	if err != nil {
 		return nil, err
 	}
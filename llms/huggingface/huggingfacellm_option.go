@@ -1,13 +1,20 @@
 package huggingface
 
+import (
+	"github.com/aresa7796/langchaingo/llms/huggingface/internal/huggingfaceclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
 const (
 	tokenEnvVarName = "HUGGINGFACEHUB_API_TOKEN"
 	defaultModel    = "gpt2"
 )
 
 type options struct {
-	token string
-	model string
+	token       string
+	model       string
+	httpClient  huggingfaceclient.Doer
+	retryPolicy *retry.Policy
 }
 
 type Option func(*options)
@@ -27,3 +34,20 @@ func WithModel(model string) Option {
 		opts.model = model
 	}
 }
+
+// WithHTTPClient allows setting a custom HTTP client. If not set, the default value
+// is http.DefaultClient.
+func WithHTTPClient(client huggingfaceclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry retries the inference requests that are rate limited or fail with
+// a server error, such as the 503 the Inference API returns while a model is
+// loading. See retry.Policy for the backoff and its defaults.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 )
 
 var (
@@ -14,20 +15,48 @@ var (
 const huggingfaceAPIBaseURL = "https://api-inference.huggingface.co"
 
 type Client struct {
-	Token string
-	Model string
-	url   string
+	Token      string
+	Model      string
+	url        string
+	httpClient Doer
 }
 
-func New(token string, model string) (*Client, error) {
+// Option is an option for the Hugging Face client.
+type Option func(*Client) error
+
+// Doer performs a HTTP request.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithHTTPClient allows setting a custom HTTP client.
+func WithHTTPClient(client Doer) Option {
+	return func(c *Client) error {
+		c.httpClient = client
+
+		return nil
+	}
+}
+
+func New(token string, model string, opts ...Option) (*Client, error) {
 	if token == "" {
 		return nil, ErrInvalidToken
 	}
-	return &Client{
-		Token: token,
-		Model: model,
-		url:   huggingfaceAPIBaseURL,
-	}, nil
+
+	c := &Client{
+		Token:      token,
+		Model:      model,
+		url:        huggingfaceAPIBaseURL,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 type InferenceRequest struct {
@@ -41,6 +70,11 @@ type InferenceRequest struct {
 	MaxLength         int           `json:"max_length,omitempty"`
 	RepetitionPenalty float64       `json:"repetition_penalty,omitempty"`
 	Seed              int           `json:"seed,omitempty"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming
+	// response. Streaming needs a model served by text-generation-inference.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 }
 
 type InferenceResponse struct {
@@ -61,6 +95,14 @@ func (c *Client) RunInference(ctx context.Context, request *InferenceRequest) (*
 			Seed:              request.Seed,
 		},
 	}
+	if request.StreamingFunc != nil {
+		payload.Stream = true
+		text, err := c.runStreamingInference(ctx, payload, request.StreamingFunc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run inference: %w", err)
+		}
+		return &InferenceResponse{Text: text}, nil
+	}
 	resp, err := c.runInference(ctx, payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run inference: %w", err)
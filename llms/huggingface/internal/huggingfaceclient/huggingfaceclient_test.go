@@ -72,3 +72,37 @@ func mockServer(t *testing.T) *httptest.Server {
 		}
 	}))
 }
+
+func TestRunInferenceStreaming(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var infReq map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&infReq))
+		require.Equal(t, true, infReq["stream"])
+
+		for _, event := range []string{
+			`{"token":{"id":1,"text":"I hug","special":false},"generated_text":null}`,
+			`{"token":{"id":2,"text":", you hug","special":false},"generated_text":null}`,
+			`{"token":{"id":0,"text":"</s>","special":true},"generated_text":"I hug, you hug"}`,
+		} {
+			fmt.Fprintf(w, "data:%s\n\n", event)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := New("token", "model")
+	require.NoError(t, err)
+	client.url = server.URL
+
+	var chunks []string
+	resp, err := client.RunInference(context.Background(), &InferenceRequest{
+		StreamingFunc: func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "I hug, you hug", resp.Text)
+	require.Equal(t, []string{"I hug", ", you hug"}, chunks)
+}
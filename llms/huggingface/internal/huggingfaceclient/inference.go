@@ -1,6 +1,7 @@
 package huggingfaceclient
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,6 +9,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
 )
 
 var ErrUnexpectedStatusCode = errors.New("unexpected status code")
@@ -24,6 +28,7 @@ type inferencePayload struct {
 	Model      string     `json:"-"`
 	Inputs     string     `json:"inputs"`
 	Parameters parameters `json:"parameters,omitempty"`
+	Stream     bool       `json:"stream,omitempty"`
 }
 
 type parameters struct {
@@ -43,7 +48,16 @@ type (
 	}
 )
 
-func (c *Client) runInference(ctx context.Context, payload *inferencePayload) (inferenceResponsePayload, error) {
+// streamedToken is an event of a streamed inference of text-generation-inference.
+type streamedToken struct {
+	Token struct {
+		Text    string `json:"text"`
+		Special bool   `json:"special"`
+	} `json:"token"`
+	GeneratedText *string `json:"generated_text"`
+}
+
+func (c *Client) newInferenceRequest(ctx context.Context, payload *inferencePayload) (*http.Request, error) {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
@@ -56,6 +70,82 @@ func (c *Client) runInference(ctx context.Context, payload *inferencePayload) (i
 	req.Header.Set("Authorization", "Bearer "+c.Token)
 	req.Header.Set("Content-Type", "application/json")
 
+	return req, nil
+}
+
+// runStreamingInference reads the server-sent events of a streamed inference,
+// sending the text of every token that is not special to the streaming func.
+// It returns the whole generated text.
+func (c *Client) runStreamingInference(
+	ctx context.Context,
+	payload *inferencePayload,
+	streamingFunc func(ctx context.Context, chunk []byte) error,
+) (string, error) {
+	req, err := c.newInferenceRequest(ctx, payload)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return "", unexpectedStatusError(r)
+	}
+
+	var text strings.Builder
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		var event streamedToken
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			return "", fmt.Errorf("failed to decode stream payload: %w", err)
+		}
+		if event.Token.Special {
+			continue
+		}
+		text.WriteString(event.Token.Text)
+		if err := streamingFunc(ctx, []byte(event.Token.Text)); err != nil {
+			return "", fmt.Errorf("streaming func returned an error: %w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if text.Len() == 0 {
+		return "", ErrEmptyResponse
+	}
+
+	return text.String(), nil
+}
+
+func unexpectedStatusError(r *http.Response) error {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	statusErr := retry.NewStatusError(r, "")
+	statusErr.Err = ErrUnexpectedStatusCode
+	if len(b) > 0 {
+		statusErr.Message = "body: " + string(b)
+	}
+	return statusErr
+}
+
+func (c *Client) runInference(ctx context.Context, payload *inferencePayload) (inferenceResponsePayload, error) {
+	req, err := c.newInferenceRequest(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
 	// debug print the http request with httputil:
 
 	// reqDump, err := httputil.DumpRequestOut(req, true)
@@ -64,24 +154,14 @@ func (c *Client) runInference(ctx context.Context, payload *inferencePayload) (i
 	// }
 	// fmt.Fprintf(os.Stderr, "%s", reqDump)
 
-	r, err := http.DefaultClient.Do(req)
+	r, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		b, err := io.ReadAll(r.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
-
-		if len(b) > 0 {
-			err = fmt.Errorf("%w: %d, body: %s", ErrUnexpectedStatusCode, r.StatusCode, string(b))
-		} else {
-			err = fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, r.StatusCode)
-		}
-		return nil, err
+		return nil, unexpectedStatusError(r)
 	}
 
 	// debug print the http response with httputil:
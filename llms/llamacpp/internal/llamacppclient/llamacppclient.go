@@ -0,0 +1,193 @@
+package llamacppclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
+const defaultBaseURL = "http://localhost:8080"
+
+// ErrEmptyResponse is returned when the llama.cpp server returns an empty response.
+var ErrEmptyResponse = errors.New("empty response")
+
+// Client is a client for the llama.cpp server API.
+type Client struct {
+	baseURL    string
+	httpClient Doer
+}
+
+// Option is an option for the llama.cpp client.
+type Option func(*Client) error
+
+// Doer performs a HTTP request.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithHTTPClient allows setting a custom HTTP client.
+func WithHTTPClient(client Doer) Option {
+	return func(c *Client) error {
+		c.httpClient = client
+		return nil
+	}
+}
+
+// WithBaseURL sets the URL of the llama.cpp server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// New returns a new llama.cpp client.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		baseURL:    defaultBaseURL,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// CompletionRequest is a request to create a completion.
+type CompletionRequest struct {
+	Prompt        string   `json:"prompt"`
+	NPredict      int      `json:"n_predict,omitempty"`
+	Temperature   float64  `json:"temperature,omitempty"`
+	TopK          int      `json:"top_k,omitempty"`
+	TopP          float64  `json:"top_p,omitempty"`
+	Seed          int      `json:"seed,omitempty"`
+	RepeatPenalty float64  `json:"repeat_penalty,omitempty"`
+	Stop          []string `json:"stop,omitempty"`
+	Stream        bool     `json:"stream"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+}
+
+// CompletionResponse is a response, or a chunk of a streamed response, to a
+// completion request.
+type CompletionResponse struct {
+	Content         string `json:"content"`
+	Model           string `json:"model"`
+	Stop            bool   `json:"stop"`
+	TokensEvaluated int    `json:"tokens_evaluated"`
+	TokensPredicted int    `json:"tokens_predicted"`
+}
+
+type embeddingRequest struct {
+	Content string `json:"content"`
+}
+
+type embeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+// CreateCompletion creates a completion. Streamed chunks are sent to the
+// streaming func of the request and the returned response holds the whole text.
+func (c *Client) CreateCompletion(ctx context.Context, r *CompletionRequest) (*CompletionResponse, error) {
+	r.Stream = r.StreamingFunc != nil
+
+	resp, err := c.post(ctx, "/completion", r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if !r.Stream {
+		var response CompletionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+			return nil, fmt.Errorf("parse response: %w", err)
+		}
+		return &response, nil
+	}
+
+	response := &CompletionResponse{}
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		chunk := CompletionResponse{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &chunk); err != nil {
+			return nil, fmt.Errorf("parse stream payload: %w", err)
+		}
+		content.WriteString(chunk.Content)
+		if chunk.Content != "" {
+			if err := r.StreamingFunc(ctx, []byte(chunk.Content)); err != nil {
+				return nil, fmt.Errorf("streaming func returned an error: %w", err)
+			}
+		}
+		*response = chunk
+		if chunk.Stop {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	response.Content = content.String()
+
+	return response, nil
+}
+
+// CreateEmbedding embeds the text.
+func (c *Client) CreateEmbedding(ctx context.Context, text string) ([]float64, error) {
+	resp, err := c.post(ctx, "/embedding", &embeddingRequest{Content: text})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if len(response.Embedding) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return response.Embedding, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, payload any) (*http.Response, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, retry.NewStatusError(resp, "")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,155 @@
+// Package llamacpp provides an LLM and embedder for the llama.cpp server.
+//
+// The server serves a single model, chosen when it is started, so the model
+// of the call options is ignored. For chat models, the OpenAI compatible
+// endpoint of the server can be used with openai.NewChat and
+// openai.WithBaseURL.
+package llamacpp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/llamacpp/internal/llamacppclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// _tokenizerModel is the model used to count tokens, as the tokenizer of the
+// served model is not known.
+const _tokenizerModel = "gpt2"
+
+var ErrEmptyResponse = errors.New("no response")
+
+// LLM is an LLM served by a llama.cpp server.
+type LLM struct {
+	CallbacksHandler callbacks.Handler
+	client           *llamacppclient.Client
+}
+
+var (
+	_ llms.LLM            = (*LLM)(nil)
+	_ llms.LanguageModel  = (*LLM)(nil)
+	_ embeddings.Embedder = (*LLM)(nil)
+)
+
+// New returns a new llama.cpp LLM.
+func New(opts ...Option) (*LLM, error) {
+	o := options{serverURL: os.Getenv(serverURLEnvVarName)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	clientOptions := []llamacppclient.Option{}
+	if o.serverURL != "" {
+		clientOptions = append(clientOptions, llamacppclient.WithBaseURL(o.serverURL))
+	}
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if o.retryPolicy != nil {
+		httpClient = retry.NewDoer(httpClient, *o.retryPolicy)
+	}
+	clientOptions = append(clientOptions, llamacppclient.WithHTTPClient(httpClient))
+
+	c, err := llamacppclient.New(clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &LLM{client: c}, nil
+}
+
+// Call requests a completion for the given prompt.
+func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	r, err := o.Generate(ctx, []string{prompt}, options...)
+	if err != nil {
+		return "", err
+	}
+	if len(r) == 0 {
+		return "", ErrEmptyResponse
+	}
+	return r[0].Text, nil
+}
+
+func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
+	}
+
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
+
+	generations := make([]*llms.Generation, 0, len(prompts))
+	for _, prompt := range prompts {
+		result, err := o.client.CreateCompletion(ctx, &llamacppclient.CompletionRequest{
+			Prompt:        prompt,
+			NPredict:      opts.MaxTokens,
+			Temperature:   opts.Temperature,
+			TopK:          opts.TopK,
+			TopP:          opts.TopP,
+			Seed:          opts.Seed,
+			RepeatPenalty: opts.RepetitionPenalty,
+			Stop:          opts.StopWords,
+			StreamingFunc: opts.StreamingFunc,
+		})
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		generations = append(generations, &llms.Generation{
+			Text: result.Content,
+			GenerationInfo: map[string]any{
+				"PromptTokens":     result.TokensEvaluated,
+				"CompletionTokens": result.TokensPredicted,
+				"TotalTokens":      result.TokensEvaluated + result.TokensPredicted,
+			},
+			Usage: llms.NewUsage(result.Model, result.TokensEvaluated, result.TokensPredicted),
+		})
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+func (o *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GeneratePrompt(ctx, o, promptValues, options...)
+}
+
+func (o *LLM) GetNumTokens(text string) int {
+	return llms.CountTokens(_tokenizerModel, text)
+}
+
+// EmbedDocuments embeds the texts. The server must be started with embeddings
+// enabled.
+func (o *LLM) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vector, err := o.client.CreateEmbedding(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+// EmbedQuery embeds the text. The server must be started with embeddings
+// enabled.
+func (o *LLM) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	return o.client.CreateEmbedding(ctx, text)
+}
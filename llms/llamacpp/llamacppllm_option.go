@@ -0,0 +1,41 @@
+package llamacpp
+
+import (
+	"github.com/aresa7796/langchaingo/llms/llamacpp/internal/llamacppclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
+const serverURLEnvVarName = "LLAMACPP_SERVER_URL"
+
+type options struct {
+	serverURL   string
+	httpClient  llamacppclient.Doer
+	retryPolicy *retry.Policy
+}
+
+type Option func(*options)
+
+// WithServerURL sets the URL of the llama.cpp server. If not set, the URL is
+// read from the LLAMACPP_SERVER_URL environment variable, and defaults to
+// http://localhost:8080.
+func WithServerURL(serverURL string) Option {
+	return func(opts *options) {
+		opts.serverURL = serverURL
+	}
+}
+
+// WithHTTPClient allows setting a custom HTTP client.
+func WithHTTPClient(client llamacppclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry makes the client retry failed requests, like server errors, with
+// exponential backoff according to the policy. Unset fields of the policy use
+// the defaults of retry.DefaultPolicy.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
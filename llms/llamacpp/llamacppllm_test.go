@@ -0,0 +1,63 @@
+package llamacpp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+
+		switch {
+		case r.URL.Path == "/embedding":
+			_, _ = w.Write([]byte(`{"embedding":[0.5,0.25]}`))
+		case req["stream"] == true:
+			for _, chunk := range []string{`{"content":"Hello","stop":false}`,
+				`{"content":" world","stop":true,"tokens_evaluated":4,"tokens_predicted":2}`} {
+				fmt.Fprintf(w, "data: %s\n\n", chunk)
+			}
+		default:
+			_, _ = w.Write([]byte(`{"content":"Hello world","stop":true,"tokens_evaluated":4,"tokens_predicted":2}`))
+		}
+	}))
+	defer server.Close()
+
+	llm, err := New(WithServerURL(server.URL))
+	require.NoError(t, err)
+
+	result, err := llm.Call(context.Background(), "Say hello", llms.WithMaxTokens(16), llms.WithStopWords([]string{"\n"}))
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", result)
+	require.Equal(t, float64(16), requests[0]["n_predict"])
+	require.Equal(t, []any{"\n"}, requests[0]["stop"])
+
+	var chunks []string
+	generations, err := llm.Generate(context.Background(), []string{"Say hello"},
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Hello", " world"}, chunks)
+	require.Equal(t, "Hello world", generations[0].Text)
+	require.Equal(t, 6, generations[0].GenerationInfo["TotalTokens"])
+	require.Equal(t, llms.NewUsage("", 4, 2), generations[0].Usage)
+
+	vector, err := llm.EmbedQuery(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.5, 0.25}, vector)
+	require.Equal(t, "hello", requests[2]["content"])
+}
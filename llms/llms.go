@@ -34,12 +34,18 @@ type Generation struct {
 	Message *schema.AIChatMessage `json:"message"`
 	// GenerationInfo is the generation info. This can contain vendor-specific information.
 	GenerationInfo map[string]any `json:"generation_info"`
+	// Usage is the token usage of the call that produced the generation, if
+	// the provider reports it. Generations from one call share the same
+	// pointer.
+	Usage *Usage `json:"usage,omitempty"`
 }
 
 // LLMResult is the class that contains all relevant information for an LLM Result.
 type LLMResult struct {
 	Generations [][]*Generation
 	LLMOutput   map[string]any
+	// Usage is the total token usage of the generations, if reported.
+	Usage *Usage
 }
 
 func GeneratePrompt(ctx context.Context, l LLM, promptValues []schema.PromptValue, options ...CallOption) (LLMResult, error) { //nolint:lll
@@ -48,9 +54,7 @@ func GeneratePrompt(ctx context.Context, l LLM, promptValues []schema.PromptValu
 		prompts = append(prompts, promptValue.String())
 	}
 	generations, err := l.Generate(ctx, prompts, options...)
-	return LLMResult{
-		Generations: [][]*Generation{generations},
-	}, err
+	return NewLLMResult(generations), err
 }
 
 func GenerateChatPrompt(ctx context.Context, l ChatLLM, promptValues []schema.PromptValue, options ...CallOption) (LLMResult, error) { //nolint:lll
@@ -59,7 +63,5 @@ func GenerateChatPrompt(ctx context.Context, l ChatLLM, promptValues []schema.Pr
 		messages = append(messages, promptValue.Messages())
 	}
 	generations, err := l.Generate(ctx, messages, options...)
-	return LLMResult{
-		Generations: [][]*Generation{generations},
-	}, err
+	return NewLLMResult(generations), err
 }
@@ -1,12 +1,20 @@
 package localclient
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"os/exec"
+	"unicode/utf8"
 )
 
+const _readBufferSize = 512
+
 type completionPayload struct {
 	Prompt string `json:"prompt"`
+
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 }
 
 type completionResponsePayload struct {
@@ -17,6 +25,10 @@ func (c *Client) createCompletion(ctx context.Context, payload *completionPayloa
 	// Append the prompt to the args
 	c.Args = append(c.Args, payload.Prompt)
 
+	if payload.StreamingFunc != nil {
+		return c.createStreamingCompletion(ctx, payload)
+	}
+
 	// #nosec G204
 	out, err := exec.CommandContext(ctx, c.BinPath, c.Args...).Output()
 	if err != nil {
@@ -27,3 +39,83 @@ func (c *Client) createCompletion(ctx context.Context, payload *completionPayloa
 		Response: string(out),
 	}, nil
 }
+
+// createStreamingCompletion runs the binary and sends its stdout to the
+// streaming func as it is written. Chunks are cut at rune boundaries.
+func (c *Client) createStreamingCompletion(
+	ctx context.Context,
+	payload *completionPayload,
+) (*completionResponsePayload, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, c.BinPath, c.Args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	var pending []byte
+	buf := make([]byte, _readBufferSize)
+	for {
+		n, readErr := stdout.Read(buf)
+		pending = append(pending, buf[:n]...)
+		complete := completeRunes(pending)
+		if len(complete) > 0 {
+			out.Write(complete)
+			if err := payload.StreamingFunc(ctx, complete); err != nil {
+				cancel()
+				_ = cmd.Wait()
+				return nil, fmt.Errorf("streaming func returned an error: %w", err)
+			}
+			pending = append([]byte(nil), pending[len(complete):]...)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			_ = cmd.Wait()
+			return nil, readErr
+		}
+	}
+	if len(pending) > 0 {
+		out.Write(pending)
+		if err := payload.StreamingFunc(ctx, pending); err != nil {
+			_ = cmd.Wait()
+			return nil, fmt.Errorf("streaming func returned an error: %w", err)
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("%w: %s", err, stderr.String())
+		}
+		return nil, err
+	}
+
+	return &completionResponsePayload{
+		Response: out.String(),
+	}, nil
+}
+
+// completeRunes returns the longest prefix of b that does not end in the middle
+// of a multi-byte rune.
+func completeRunes(b []byte) []byte {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(b[i]) {
+			continue
+		}
+		if utf8.FullRune(b[i:]) {
+			return b
+		}
+		return b[:i]
+	}
+	return b
+}
@@ -0,0 +1,43 @@
+package localclient
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCompletionStreaming(t *testing.T) {
+	t.Parallel()
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not found")
+	}
+
+	// The prompt is appended to the args, so it is $0 of the script.
+	c, err := New(sh, false, "-c", `printf 'héllo '; sleep 0.05; printf "$0"`)
+	require.NoError(t, err)
+
+	var chunks []string
+	completion, err := c.CreateCompletion(context.Background(), &CompletionRequest{
+		Prompt: "wörld",
+		StreamingFunc: func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "héllo wörld", completion.Text)
+	require.Equal(t, []string{"héllo ", "wörld"}, chunks)
+}
+
+func TestCompleteRunes(t *testing.T) {
+	t.Parallel()
+
+	b := []byte("hé")
+	require.Equal(t, []byte("h"), completeRunes(b[:2]))
+	require.Equal(t, b, completeRunes(b))
+	require.Equal(t, []byte("abc"), completeRunes([]byte("abc")))
+}
@@ -24,6 +24,10 @@ func New(binPath string, globalAsArgs bool, args ...string) (*Client, error) {
 // CompletionRequest is a request to create a completion.
 type CompletionRequest struct {
 	Prompt string `json:"prompt"`
+
+	// StreamingFunc is a function to be called for each chunk of the output of
+	// the binary as it is written. Return an error to stop the binary early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 }
 
 // Completion is a completion.
@@ -34,7 +38,8 @@ type Completion struct {
 // CreateCompletion creates a completion.
 func (c *Client) CreateCompletion(ctx context.Context, r *CompletionRequest) (*Completion, error) {
 	resp, err := c.createCompletion(ctx, &completionPayload{
-		Prompt: r.Prompt,
+		Prompt:        r.Prompt,
+		StreamingFunc: r.StreamingFunc,
 	})
 	if err != nil {
 		return nil, err
@@ -73,14 +73,17 @@ func (o *LLM) appendGlobalsToArgs(opts llms.CallOptions) []string {
 
 // Generate generates completions using the local LLM binary.
 func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, prompts)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
 	}
 
 	opts := &llms.CallOptions{}
 	for _, opt := range options {
 		opt(opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 
 	// If o.client.GlobalAsArgs is true
 	if o.client.GlobalAsArgs {
@@ -91,17 +94,21 @@ func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.Ca
 	generations := make([]*llms.Generation, 0, len(prompts))
 	for _, prompt := range prompts {
 		result, err := o.client.CreateCompletion(ctx, &localclient.CompletionRequest{
-			Prompt: prompt,
+			Prompt:        prompt,
+			StreamingFunc: opts.StreamingFunc,
 		})
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 
 		generations = append(generations, &llms.Generation{Text: result.Text})
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 
 	return generations, nil
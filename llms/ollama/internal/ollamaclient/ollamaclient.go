@@ -0,0 +1,285 @@
+package ollamaclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
+const defaultBaseURL = "http://localhost:11434"
+
+// ErrEmptyResponse is returned when the Ollama API returns an empty response.
+var ErrEmptyResponse = errors.New("empty response")
+
+// Client is a client for the Ollama API.
+type Client struct {
+	Model      string
+	baseURL    string
+	httpClient Doer
+}
+
+// Option is an option for the Ollama client.
+type Option func(*Client) error
+
+// Doer performs a HTTP request.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WithHTTPClient allows setting a custom HTTP client.
+func WithHTTPClient(client Doer) Option {
+	return func(c *Client) error {
+		c.httpClient = client
+		return nil
+	}
+}
+
+// WithBaseURL sets the URL of the Ollama server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		return nil
+	}
+}
+
+// New returns a new Ollama client.
+func New(model string, opts ...Option) (*Client, error) {
+	c := &Client{
+		Model:      model,
+		baseURL:    defaultBaseURL,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Options are the model parameters of a request.
+type Options struct {
+	NumPredict    int      `json:"num_predict,omitempty"`
+	Temperature   float64  `json:"temperature,omitempty"`
+	TopK          int      `json:"top_k,omitempty"`
+	TopP          float64  `json:"top_p,omitempty"`
+	Seed          int      `json:"seed,omitempty"`
+	RepeatPenalty float64  `json:"repeat_penalty,omitempty"`
+	Stop          []string `json:"stop,omitempty"`
+}
+
+// GenerateRequest is a request to generate a completion.
+type GenerateRequest struct {
+	Model   string   `json:"model"`
+	Prompt  string   `json:"prompt"`
+	System  string   `json:"system,omitempty"`
+	Format  string   `json:"format,omitempty"`
+	Options *Options `json:"options,omitempty"`
+	Stream  bool     `json:"stream"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+}
+
+// GenerateResponse is a response, or a chunk of a streamed response, to a
+// generate request.
+type GenerateResponse struct {
+	Model           string `json:"model"`
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+// Message is a chat message.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatRequest is a request to generate a chat response.
+type ChatRequest struct {
+	Model    string     `json:"model"`
+	Messages []*Message `json:"messages"`
+	Format   string     `json:"format,omitempty"`
+	Options  *Options   `json:"options,omitempty"`
+	Stream   bool       `json:"stream"`
+
+	// StreamingFunc is a function to be called for each chunk of a streaming response.
+	// Return an error to stop streaming early.
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
+}
+
+// ChatResponse is a response, or a chunk of a streamed response, to a chat
+// request.
+type ChatResponse struct {
+	Model           string   `json:"model"`
+	Message         *Message `json:"message"`
+	Done            bool     `json:"done"`
+	PromptEvalCount int      `json:"prompt_eval_count"`
+	EvalCount       int      `json:"eval_count"`
+}
+
+// EmbeddingRequest is a request to embed a text.
+type EmbeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type embeddingResponse struct {
+	Embedding []float64 `json:"embedding"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Generate generates a completion. Streamed chunks are sent to the streaming
+// func of the request and the returned response holds the whole text.
+func (c *Client) Generate(ctx context.Context, r *GenerateRequest) (*GenerateResponse, error) {
+	if r.Model == "" {
+		r.Model = c.Model
+	}
+	r.Stream = r.StreamingFunc != nil
+
+	response := &GenerateResponse{}
+	var text strings.Builder
+	err := c.do(ctx, "/api/generate", r, func(data []byte) error {
+		chunk := GenerateResponse{}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("parse response: %w", err)
+		}
+		text.WriteString(chunk.Response)
+		if r.StreamingFunc != nil && chunk.Response != "" {
+			if err := r.StreamingFunc(ctx, []byte(chunk.Response)); err != nil {
+				return fmt.Errorf("streaming func returned an error: %w", err)
+			}
+		}
+		*response = chunk
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Response = text.String()
+
+	return response, nil
+}
+
+// Chat generates a chat response. Streamed chunks are sent to the streaming
+// func of the request and the returned response holds the whole message.
+func (c *Client) Chat(ctx context.Context, r *ChatRequest) (*ChatResponse, error) {
+	if r.Model == "" {
+		r.Model = c.Model
+	}
+	r.Stream = r.StreamingFunc != nil
+
+	response := &ChatResponse{}
+	var content strings.Builder
+	err := c.do(ctx, "/api/chat", r, func(data []byte) error {
+		chunk := ChatResponse{}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("parse response: %w", err)
+		}
+		if chunk.Message != nil {
+			content.WriteString(chunk.Message.Content)
+			if r.StreamingFunc != nil && chunk.Message.Content != "" {
+				if err := r.StreamingFunc(ctx, []byte(chunk.Message.Content)); err != nil {
+					return fmt.Errorf("streaming func returned an error: %w", err)
+				}
+			}
+		}
+		*response = chunk
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Message = &Message{Role: "assistant", Content: content.String()}
+
+	return response, nil
+}
+
+// CreateEmbedding embeds the text.
+func (c *Client) CreateEmbedding(ctx context.Context, r *EmbeddingRequest) ([]float64, error) {
+	if r.Model == "" {
+		r.Model = c.Model
+	}
+
+	response := embeddingResponse{}
+	err := c.do(ctx, "/api/embeddings", r, func(data []byte) error {
+		return json.Unmarshal(data, &response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Embedding) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return response.Embedding, nil
+}
+
+// do posts the payload to the path and calls handle with every line of the
+// response. Ollama answers with one JSON object per line when streaming, and
+// a single JSON object otherwise.
+func (c *Client) do(ctx context.Context, path string, payload any, handle func([]byte) error) error {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		_ = json.NewDecoder(r.Body).Decode(&errResp)
+		return retry.NewStatusError(r, errResp.Error)
+	}
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	received := false
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		received = true
+		var errResp errorResponse
+		if err := json.Unmarshal(line, &errResp); err == nil && errResp.Error != "" {
+			return fmt.Errorf("API returned an error: %s", errResp.Error) // nolint:goerr113
+		}
+		if err := handle(line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if !received {
+		return ErrEmptyResponse
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// Package ollama provides an LLM, a chat LLM and embeddings for models served by
+// Ollama. The model is pulled by the Ollama server, see https://ollama.ai.
+package ollama
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/ollama/internal/ollamaclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+var (
+	ErrEmptyResponse          = errors.New("no response")
+	ErrUnsupportedMessageType = errors.New("unsupported message type")
+)
+
+// LLM is a completion LLM served by Ollama.
+type LLM struct {
+	CallbacksHandler callbacks.Handler
+	client           *ollamaclient.Client
+	options          options
+}
+
+var (
+	_ llms.LLM            = (*LLM)(nil)
+	_ llms.LanguageModel  = (*LLM)(nil)
+	_ embeddings.Embedder = (*LLM)(nil)
+)
+
+// New returns a new Ollama LLM.
+func New(opts ...Option) (*LLM, error) {
+	o, c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &LLM{client: c, options: o}, nil
+}
+
+func newClient(opts ...Option) (options, *ollamaclient.Client, error) {
+	o := options{
+		model:     defaultModel,
+		serverURL: os.Getenv(hostEnvVarName),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	clientOptions := []ollamaclient.Option{}
+	if o.serverURL != "" {
+		clientOptions = append(clientOptions, ollamaclient.WithBaseURL(o.serverURL))
+	}
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if o.retryPolicy != nil {
+		httpClient = retry.NewDoer(httpClient, *o.retryPolicy)
+	}
+	clientOptions = append(clientOptions, ollamaclient.WithHTTPClient(httpClient))
+
+	c, err := ollamaclient.New(o.model, clientOptions...)
+	return o, c, err
+}
+
+// Call requests a completion for the given prompt.
+func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	r, err := o.Generate(ctx, []string{prompt}, options...)
+	if err != nil {
+		return "", err
+	}
+	if len(r) == 0 {
+		return "", ErrEmptyResponse
+	}
+	return r[0].Text, nil
+}
+
+func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
+	}
+
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
+
+	generations := make([]*llms.Generation, 0, len(prompts))
+	for _, prompt := range prompts {
+		result, err := o.client.Generate(ctx, &ollamaclient.GenerateRequest{
+			Model:         opts.Model,
+			Prompt:        prompt,
+			System:        o.options.system,
+			Format:        o.options.format,
+			Options:       modelOptions(opts),
+			StreamingFunc: opts.StreamingFunc,
+		})
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		generations = append(generations, &llms.Generation{
+			Text:           result.Response,
+			GenerationInfo: generationInfo(result.PromptEvalCount, result.EvalCount),
+			Usage:          llms.NewUsage(result.Model, result.PromptEvalCount, result.EvalCount),
+		})
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+func (o *LLM) GeneratePrompt(ctx context.Context, promptValues []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GeneratePrompt(ctx, o, promptValues, options...)
+}
+
+func (o *LLM) GetNumTokens(text string) int {
+	return llms.CountTokens(o.client.Model, text)
+}
+
+// EmbedDocuments embeds the texts with the model of the LLM.
+func (o *LLM) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return embedDocuments(ctx, o.client, texts)
+}
+
+// EmbedQuery embeds the text with the model of the LLM.
+func (o *LLM) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	return o.client.CreateEmbedding(ctx, &ollamaclient.EmbeddingRequest{Prompt: text})
+}
+
+func embedDocuments(ctx context.Context, client *ollamaclient.Client, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		vector, err := client.CreateEmbedding(ctx, &ollamaclient.EmbeddingRequest{Prompt: text})
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+// modelOptions maps the call options to the model parameters of Ollama.
+func modelOptions(opts llms.CallOptions) *ollamaclient.Options {
+	return &ollamaclient.Options{
+		NumPredict:    opts.MaxTokens,
+		Temperature:   opts.Temperature,
+		TopK:          opts.TopK,
+		TopP:          opts.TopP,
+		Seed:          opts.Seed,
+		RepeatPenalty: opts.RepetitionPenalty,
+		Stop:          opts.StopWords,
+	}
+}
+
+func generationInfo(promptTokens, completionTokens int) map[string]any {
+	return map[string]any{
+		"PromptTokens":     promptTokens,
+		"CompletionTokens": completionTokens,
+		"TotalTokens":      promptTokens + completionTokens,
+	}
+}
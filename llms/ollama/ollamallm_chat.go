@@ -0,0 +1,153 @@
+package ollama
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/ollama/internal/ollamaclient"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+const (
+	RoleSystem    = "system"
+	RoleAssistant = "assistant"
+	RoleUser      = "user"
+)
+
+// Chat is a chat LLM served by Ollama.
+type Chat struct {
+	CallbacksHandler callbacks.Handler
+	client           *ollamaclient.Client
+	options          options
+}
+
+var (
+	_ llms.ChatLLM        = (*Chat)(nil)
+	_ llms.LanguageModel  = (*Chat)(nil)
+	_ embeddings.Embedder = (*Chat)(nil)
+)
+
+// NewChat returns a new Ollama chat LLM.
+func NewChat(opts ...Option) (*Chat, error) {
+	o, c, err := newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Chat{client: c, options: o}, nil
+}
+
+// Call requests a chat response for the given messages.
+func (o *Chat) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { // nolint: lll
+	r, err := o.Generate(ctx, [][]schema.ChatMessage{messages}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(r) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	return r[0].Message, nil
+}
+
+func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { // nolint:lll
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
+	}
+
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
+
+	generations := make([]*llms.Generation, 0, len(messageSets))
+	for _, messages := range messageSets {
+		clientMessages, err := messagesToClientMessages(messages)
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		result, err := o.client.Chat(ctx, &ollamaclient.ChatRequest{
+			Model:         opts.Model,
+			Messages:      clientMessages,
+			Format:        o.options.format,
+			Options:       modelOptions(opts),
+			StreamingFunc: opts.StreamingFunc,
+		})
+		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
+			return nil, err
+		}
+		msg := &schema.AIChatMessage{Content: result.Message.Content}
+		generations = append(generations, &llms.Generation{
+			Message:        msg,
+			Text:           msg.Content,
+			GenerationInfo: generationInfo(result.PromptEvalCount, result.EvalCount),
+			Usage:          llms.NewUsage(result.Model, result.PromptEvalCount, result.EvalCount),
+		})
+	}
+
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
+	return generations, nil
+}
+
+func (o *Chat) GetNumTokens(text string) int {
+	return llms.CountTokens(o.client.Model, text)
+}
+
+func (o *Chat) GeneratePrompt(ctx context.Context, promptValues []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GenerateChatPrompt(ctx, o, promptValues, options...)
+}
+
+// EmbedDocuments embeds the texts with the model of the chat LLM.
+func (o *Chat) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	return embedDocuments(ctx, o.client, texts)
+}
+
+// EmbedQuery embeds the text with the model of the chat LLM.
+func (o *Chat) EmbedQuery(ctx context.Context, text string) ([]float64, error) {
+	return o.client.CreateEmbedding(ctx, &ollamaclient.EmbeddingRequest{Prompt: text})
+}
+
+func messagesToClientMessages(messages []schema.ChatMessage) ([]*ollamaclient.Message, error) {
+	msgs := make([]*ollamaclient.Message, len(messages))
+	for i, m := range messages {
+		msg := &ollamaclient.Message{Content: m.GetContent()}
+		switch m.GetType() {
+		case schema.ChatMessageTypeSystem:
+			msg.Role = RoleSystem
+		case schema.ChatMessageTypeAI:
+			msg.Role = RoleAssistant
+		case schema.ChatMessageTypeHuman, schema.ChatMessageTypeGeneric:
+			msg.Role = RoleUser
+		case schema.ChatMessageTypeFunction, schema.ChatMessageTypeTool:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMessageType, m.GetType())
+		}
+		msgs[i] = msg
+	}
+
+	return msgs, nil
+}
+
+func getPromptsFromMessageSets(messageSets [][]schema.ChatMessage) []string {
+	prompts := make([]string, 0, len(messageSets))
+	for i := 0; i < len(messageSets); i++ {
+		curPrompt := ""
+		for j := 0; j < len(messageSets[i]); j++ {
+			curPrompt += messageSets[i][j].GetContent()
+		}
+		prompts = append(prompts, curPrompt)
+	}
+
+	return prompts
+}
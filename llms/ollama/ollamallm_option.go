@@ -0,0 +1,70 @@
+package ollama
+
+import (
+	"github.com/aresa7796/langchaingo/llms/ollama/internal/ollamaclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
+
+const (
+	hostEnvVarName = "OLLAMA_HOST"
+	defaultModel   = "llama2"
+)
+
+type options struct {
+	model       string
+	serverURL   string
+	format      string
+	system      string
+	httpClient  ollamaclient.Doer
+	retryPolicy *retry.Policy
+}
+
+type Option func(*options)
+
+// WithModel sets the model to use. The default is llama2.
+func WithModel(model string) Option {
+	return func(opts *options) {
+		opts.model = model
+	}
+}
+
+// WithServerURL sets the URL of the Ollama server. If not set, the URL is read
+// from the OLLAMA_HOST environment variable, and defaults to
+// http://localhost:11434.
+func WithServerURL(serverURL string) Option {
+	return func(opts *options) {
+		opts.serverURL = serverURL
+	}
+}
+
+// WithFormat sets the format of the responses. The only format supported by
+// Ollama is "json".
+func WithFormat(format string) Option {
+	return func(opts *options) {
+		opts.format = format
+	}
+}
+
+// WithSystemPrompt sets the system prompt of completions, overriding the one of
+// the model file.
+func WithSystemPrompt(system string) Option {
+	return func(opts *options) {
+		opts.system = system
+	}
+}
+
+// WithHTTPClient allows setting a custom HTTP client.
+func WithHTTPClient(client ollamaclient.Doer) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithRetry makes the client retry failed requests, like server errors, with
+// exponential backoff according to the policy. Unset fields of the policy use
+// the defaults of retry.DefaultPolicy.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
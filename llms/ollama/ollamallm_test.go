@@ -0,0 +1,190 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a stand-in of the Ollama API that answers with the
+// words of the reply, one per line when streaming, and records the requests.
+func newTestServer(t *testing.T, reply []string, requests *[]map[string]any) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+
+		enc := json.NewEncoder(w)
+		switch r.URL.Path {
+		case "/api/embeddings":
+			require.NoError(t, enc.Encode(map[string]any{"embedding": []float64{0.1, float64(len(req["prompt"].(string)))}}))
+		case "/api/generate", "/api/chat":
+			chunks := reply
+			if req["stream"] == false {
+				chunks = []string{strings.Join(reply, "")}
+			}
+			for i, chunk := range chunks {
+				resp := map[string]any{"model": req["model"], "done": i == len(chunks)-1}
+				if r.URL.Path == "/api/chat" {
+					resp["message"] = map[string]any{"role": "assistant", "content": chunk}
+				} else {
+					resp["response"] = chunk
+				}
+				if i == len(chunks)-1 {
+					resp["prompt_eval_count"] = 5
+					resp["eval_count"] = 3
+				}
+				require.NoError(t, enc.Encode(resp))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			require.NoError(t, enc.Encode(map[string]any{"error": "not found"}))
+		}
+	}))
+}
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	server := newTestServer(t, []string{"Hello", " world"}, &requests)
+	defer server.Close()
+
+	llm, err := New(WithServerURL(server.URL), WithModel("mistral"))
+	require.NoError(t, err)
+
+	result, err := llm.Call(context.Background(), "Say hello", llms.WithTemperature(0.2), llms.WithMaxTokens(10))
+	require.NoError(t, err)
+	require.Equal(t, "Hello world", result)
+	require.Equal(t, "mistral", requests[0]["model"])
+	require.Equal(t, false, requests[0]["stream"])
+	require.Equal(t, map[string]any{"temperature": 0.2, "num_predict": float64(10)}, requests[0]["options"])
+
+	var chunks []string
+	generations, err := llm.Generate(context.Background(), []string{"Say hello"},
+		llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"Hello", " world"}, chunks)
+	require.Equal(t, "Hello world", generations[0].Text)
+	require.Equal(t, 8, generations[0].GenerationInfo["TotalTokens"])
+	require.Equal(t, 8, generations[0].Usage.TotalTokens)
+
+	vectors, err := llm.EmbedDocuments(context.Background(), []string{"a", "bb"})
+	require.NoError(t, err)
+	require.Equal(t, [][]float64{{0.1, 1}, {0.1, 2}}, vectors)
+}
+
+func TestChat(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	server := newTestServer(t, []string{"Hi", "!"}, &requests)
+	defer server.Close()
+
+	chat, err := NewChat(WithServerURL(server.URL))
+	require.NoError(t, err)
+	recorder := &eventRecorder{}
+	chat.CallbacksHandler = recorder
+
+	var chunks []string
+	msg, err := chat.Call(context.Background(), []schema.ChatMessage{
+		schema.SystemChatMessage{Content: "Be brief."},
+		schema.HumanChatMessage{Content: "Hello"},
+	}, llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "Hi!", msg.Content)
+	require.Equal(t, []string{"Hi", "!"}, chunks)
+	require.Equal(t, chunks, recorder.tokens)
+	require.Equal(t, defaultModel, requests[0]["model"])
+	require.Equal(t, []any{
+		map[string]any{"role": "system", "content": "Be brief."},
+		map[string]any{"role": "user", "content": "Hello"},
+	}, requests[0]["messages"])
+
+	_, err = chat.Call(context.Background(), []schema.ChatMessage{schema.FunctionChatMessage{Name: "f"}})
+	require.ErrorIs(t, err, ErrUnsupportedMessageType)
+}
+
+func TestLLMError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model 'missing' not found"}`))
+	}))
+	defer server.Close()
+
+	llm, err := New(WithServerURL(server.URL), WithModel("missing"))
+	require.NoError(t, err)
+	recorder := &eventRecorder{}
+	llm.CallbacksHandler = recorder
+
+	ctx := callbacks.StartRun(context.Background())
+	_, err = llm.Call(ctx, "hello")
+	require.ErrorContains(t, err, "404")
+	require.ErrorContains(t, err, "not found")
+
+	// The error is reported in the run of the call, nested in the run of ctx.
+	require.Equal(t, []error{err}, recorder.errs)
+	require.Len(t, recorder.runs, 2)
+	require.Equal(t, recorder.runs[0], recorder.runs[1])
+	require.Equal(t, callbacks.RunID(ctx), recorder.parentRuns[0])
+}
+
+// eventRecorder records the llm callbacks and the runs they belong to.
+type eventRecorder struct {
+	callbacks.SimpleHandler
+	tokens     []string
+	errs       []error
+	runs       []string
+	parentRuns []string
+}
+
+func (r *eventRecorder) record(ctx context.Context) {
+	r.runs = append(r.runs, callbacks.RunID(ctx))
+	r.parentRuns = append(r.parentRuns, callbacks.ParentRunID(ctx))
+}
+
+func (r *eventRecorder) HandleLLMStart(ctx context.Context, _ []string) {
+	r.record(ctx)
+}
+
+func (r *eventRecorder) HandleLLMNewToken(_ context.Context, token string) {
+	r.tokens = append(r.tokens, token)
+}
+
+func (r *eventRecorder) HandleLLMError(ctx context.Context, err error) {
+	r.record(ctx)
+	r.errs = append(r.errs, err)
+}
+
+func TestLLMWithOllama(t *testing.T) {
+	t.Parallel()
+
+	if host := os.Getenv(hostEnvVarName); host == "" {
+		t.Skip("OLLAMA_HOST not set")
+	}
+
+	llm, err := New()
+	require.NoError(t, err)
+
+	_, err = llm.Call(context.Background(), "Say hello")
+	require.NoError(t, err)
+}
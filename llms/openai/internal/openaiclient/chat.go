@@ -10,12 +10,18 @@ import (
 	"log"
 	"net/http"
 	"strings"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
 )
 
 const (
 	defaultChatModel = "gpt-3.5-turbo"
 )
 
+// ErrUnexpectedChoiceIndex is returned when a streamed choice or tool call has
+// a negative index, or a choice an index out of the range of the choices.
+var ErrUnexpectedChoiceIndex = errors.New("unexpected choice index")
+
 // ChatRequest is a request to complete a chat completion..
 type ChatRequest struct {
 	Model            string         `json:"model"`
@@ -37,6 +43,15 @@ type ChatRequest struct {
 	// `{"name": "my_function"}`
 	FunctionCallBehavior FunctionCallBehavior `json:"function_call,omitempty"`
 
+	// Tools are the tools the model can call.
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice is the tool the model must call: "none", "auto", "required" or
+	// the name of a function.
+	ToolChoice ToolChoice `json:"tool_choice,omitempty"`
+	// ResponseFormat is the format of the response, set to "json_object" for
+	// JSON mode.
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
 	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
@@ -54,6 +69,10 @@ type ChatMessage struct {
 
 	// FunctionCall represents a function call to be made in the message.
 	FunctionCall *FunctionCall `json:"function_call,omitempty"`
+	// ToolCalls are the tool calls made in the message.
+	ToolCalls []*ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID is the id of the tool call a tool message is the result of.
+	ToolCallID string `json:"tool_call_id,omitempty"`
 }
 
 // ChatChoice is a choice in a chat response.
@@ -93,12 +112,16 @@ type StreamedChatResponsePayload struct {
 	Choices []struct {
 		Index float64 `json:"index,omitempty"`
 		Delta struct {
-			Role         string        `json:"role,omitempty"`
-			Content      string        `json:"content,omitempty"`
-			FunctionCall *FunctionCall `json:"function_call,omitempty"`
+			Role         string              `json:"role,omitempty"`
+			Content      string              `json:"content,omitempty"`
+			FunctionCall *FunctionCall       `json:"function_call,omitempty"`
+			ToolCalls    []*StreamedToolCall `json:"tool_calls,omitempty"`
 		} `json:"delta,omitempty"`
 		FinishReason string `json:"finish_reason,omitempty"`
 	} `json:"choices,omitempty"`
+	// Usage is only sent, in the last chunk, by servers that report the usage
+	// of streamed responses.
+	Usage *ChatUsage `json:"usage,omitempty"`
 }
 
 // FunctionDefinition is a definition of a function that can be called by the model.
@@ -111,7 +134,8 @@ type FunctionDefinition struct {
 	Parameters any `json:"parameters"`
 }
 
-// FunctionCallBehavior is the behavior to use when calling functions.
+// FunctionCallBehavior is the behavior to use when calling functions. Values
+// other than the constants are the name of the function the model must call.
 type FunctionCallBehavior string
 
 const (
@@ -123,6 +147,61 @@ const (
 	FunctionCallBehaviorAuto FunctionCallBehavior = "auto"
 )
 
+// MarshalJSON encodes the behavior as a string, or as an object naming the
+// function the model must call.
+func (b FunctionCallBehavior) MarshalJSON() ([]byte, error) {
+	switch {
+	case b == FunctionCallBehaviorUnspecified, b == FunctionCallBehaviorNone, b == FunctionCallBehaviorAuto:
+		return json.Marshal(string(b))
+	case json.Valid([]byte(b)) && strings.HasPrefix(string(b), "{"):
+		return []byte(b), nil
+	}
+	return json.Marshal(map[string]string{"name": string(b)})
+}
+
+// Tool is a tool the model can call.
+type Tool struct {
+	Type     string              `json:"type"`
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// ToolChoice is the tool the model must call. Values other than "none", "auto"
+// and "required" are the name of the function the model must call.
+type ToolChoice string
+
+// MarshalJSON encodes the choice as a string, or as an object naming the
+// function the model must call.
+func (c ToolChoice) MarshalJSON() ([]byte, error) {
+	switch c {
+	case "", "none", "auto", "required":
+		return json.Marshal(string(c))
+	}
+	return json.Marshal(map[string]any{
+		"type":     "function",
+		"function": map[string]string{"name": string(c)},
+	})
+}
+
+// ToolCall is a call to a tool.
+type ToolCall struct {
+	ID       string        `json:"id"`
+	Type     string        `json:"type"`
+	Function *FunctionCall `json:"function"`
+}
+
+// StreamedToolCall is a chunk of a tool call from the stream.
+type StreamedToolCall struct {
+	Index    int           `json:"index"`
+	ID       string        `json:"id,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	Function *FunctionCall `json:"function,omitempty"`
+}
+
+// ResponseFormat is the format of the response.
+type ResponseFormat struct {
+	Type string `json:"type"`
+}
+
 // FunctionCall is a call to a function.
 type FunctionCall struct {
 	// Name is the name of the function to call.
@@ -161,16 +240,12 @@ func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatRes
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		msg := fmt.Sprintf("API returned unexpected status code: %d", r.StatusCode)
-
 		// No need to check the error here: if it fails, we'll just return the
 		// status code.
 		var errResp errorMessage
-		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg) // nolint:goerr113
-		}
+		_ = json.NewDecoder(r.Body).Decode(&errResp)
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message) // nolint:goerr113
+		return nil, retry.NewStatusError(r, errResp.Error.Message)
 	}
 	if payload.StreamingFunc != nil {
 		return parseStreamingChatResponse(ctx, r, payload)
@@ -209,33 +284,95 @@ func parseStreamingChatResponse(ctx context.Context, r *http.Response, payload *
 		}
 	}()
 	// Parse response
-	response := ChatResponse{
-		Choices: []*ChatChoice{
-			{},
-		},
-	}
-
+	response := ChatResponse{}
+	choices := map[int]*ChatChoice{}
 	for streamResponse := range responseChan {
-		if len(streamResponse.Choices) == 0 {
-			continue
+		if streamResponse.Model != "" {
+			response.Model = streamResponse.Model
 		}
-		chunk := []byte(streamResponse.Choices[0].Delta.Content)
-		response.Choices[0].Message.Content += streamResponse.Choices[0].Delta.Content
-		if streamResponse.Choices[0].Delta.FunctionCall != nil {
-			if response.Choices[0].Message.FunctionCall == nil {
-				response.Choices[0].Message.FunctionCall = streamResponse.Choices[0].Delta.FunctionCall
-			} else {
-				response.Choices[0].Message.FunctionCall.Arguments += streamResponse.Choices[0].Delta.FunctionCall.Arguments
-			}
-			chunk, _ = json.Marshal(response.Choices[0].Message.FunctionCall) // nolint:errchkjson
+		if streamResponse.Usage != nil {
+			response.Usage.PromptTokens = float64(streamResponse.Usage.PromptTokens)
+			response.Usage.CompletionTokens = float64(streamResponse.Usage.CompletionTokens)
+			response.Usage.TotalTokens = float64(streamResponse.Usage.TotalTokens)
 		}
+		for _, streamChoice := range streamResponse.Choices {
+			index := int(streamChoice.Index)
+			if index < 0 {
+				return nil, fmt.Errorf("%w: choice index %d", ErrUnexpectedChoiceIndex, index)
+			}
+			choice, ok := choices[index]
+			if !ok {
+				choice = &ChatChoice{Index: index}
+				choices[index] = choice
+			}
+			if streamChoice.FinishReason != "" {
+				choice.FinishReason = streamChoice.FinishReason
+			}
 
-		if payload.StreamingFunc != nil {
-			err := payload.StreamingFunc(ctx, chunk)
-			if err != nil {
-				return nil, fmt.Errorf("streaming func returned an error: %w", err)
+			chunk := []byte(streamChoice.Delta.Content)
+			choice.Message.Content += streamChoice.Delta.Content
+			if streamChoice.Delta.FunctionCall != nil {
+				if choice.Message.FunctionCall == nil {
+					choice.Message.FunctionCall = streamChoice.Delta.FunctionCall
+				} else {
+					choice.Message.FunctionCall.Arguments += streamChoice.Delta.FunctionCall.Arguments
+				}
+				chunk, _ = json.Marshal(choice.Message.FunctionCall) // nolint:errchkjson
+			}
+			if len(streamChoice.Delta.ToolCalls) > 0 {
+				toolCalls, err := mergeToolCalls(choice.Message.ToolCalls, streamChoice.Delta.ToolCalls)
+				if err != nil {
+					return nil, err
+				}
+				choice.Message.ToolCalls = toolCalls
+				chunk, _ = json.Marshal(choice.Message.ToolCalls) // nolint:errchkjson
+			}
+
+			// Only the first choice is streamed, so the chunks of different
+			// choices are not mixed.
+			if payload.StreamingFunc != nil && index == 0 && len(chunk) > 0 {
+				err := payload.StreamingFunc(ctx, chunk)
+				if err != nil {
+					return nil, fmt.Errorf("streaming func returned an error: %w", err)
+				}
 			}
 		}
 	}
+
+	response.Choices = make([]*ChatChoice, len(choices))
+	for index, choice := range choices {
+		if index >= len(choices) {
+			return nil, fmt.Errorf("%w: choice index %d", ErrUnexpectedChoiceIndex, index)
+		}
+		response.Choices[index] = choice
+	}
+	if len(response.Choices) == 0 {
+		response.Choices = []*ChatChoice{{}}
+	}
 	return &response, nil
 }
+
+// mergeToolCalls adds the chunks of tool calls to the tool calls received so
+// far. The chunks of a call share its index, only the first one has its id.
+func mergeToolCalls(calls []*ToolCall, chunks []*StreamedToolCall) ([]*ToolCall, error) {
+	for _, chunk := range chunks {
+		if chunk.Index < 0 {
+			return nil, fmt.Errorf("%w: tool call index %d", ErrUnexpectedChoiceIndex, chunk.Index)
+		}
+		for len(calls) <= chunk.Index {
+			calls = append(calls, &ToolCall{Function: &FunctionCall{}})
+		}
+		call := calls[chunk.Index]
+		if chunk.ID != "" {
+			call.ID = chunk.ID
+		}
+		if chunk.Type != "" {
+			call.Type = chunk.Type
+		}
+		if chunk.Function != nil {
+			call.Function.Name += chunk.Function.Name
+			call.Function.Arguments += chunk.Function.Arguments
+		}
+	}
+	return calls, nil
+}
@@ -74,6 +74,10 @@ func New(token string, model string, baseURL string, organization string,
 // Completion is a completion.
 type Completion struct {
 	Text string `json:"text"`
+	// Model is the model that generated the completion.
+	Model string `json:"model"`
+	// Usage is the token usage of the request.
+	Usage ChatUsage `json:"usage"`
 }
 
 // CreateCompletion creates a completion.
@@ -89,7 +93,13 @@ func (c *Client) CreateCompletion(ctx context.Context, r *CompletionRequest) (*C
 	//fmt.Printf("req===>:%s\n\n", r.Prompt)
 	//fmt.Printf("resp===>:%s\n\n", resp.Choices[0].Message.Content)
 	return &Completion{
-		Text: resp.Choices[0].Message.Content,
+		Text:  resp.Choices[0].Message.Content,
+		Model: resp.Model,
+		Usage: ChatUsage{
+			PromptTokens:     int(resp.Usage.PromptTokens),
+			CompletionTokens: int(resp.Usage.CompletionTokens),
+			TotalTokens:      int(resp.Usage.TotalTokens),
+		},
 	}, nil
 }
 
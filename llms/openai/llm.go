@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/aresa7796/langchaingo/llms/openai/internal/openaiclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
 )
 
 var (
@@ -43,6 +44,10 @@ func newClient(opts ...Option) (*openaiclient.Client, error) {
 		return nil, ErrMissingToken
 	}
 
+	if options.retryPolicy != nil {
+		options.httpClient = retry.NewDoer(options.httpClient, *options.retryPolicy)
+	}
+
 	return openaiclient.New(options.token, options.model, options.baseURL, options.organization,
 		openaiclient.APIType(options.apiType), options.apiVersion, options.httpClient, options.embeddingModel)
 }
@@ -49,72 +49,132 @@ func (o *Chat) Call(ctx context.Context, messages []schema.ChatMessage, options
 	return r[0].Message, nil
 }
 
-//nolint:funlen
-func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { // nolint:lll,cyclop
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
+// Generate requests a chat response for each set of messages. If more than one
+// choice is asked for with llms.WithN, all the choices of a set are returned as
+// separate generations, one set after another.
+func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { // nolint:lll
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
 	}
 
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 	generations := make([]*llms.Generation, 0, len(messageSets))
 	for _, messageSet := range messageSets {
-		req := &openaiclient.ChatRequest{
-			Model:            opts.Model,
-			StopWords:        opts.StopWords,
-			Messages:         messagesToClientMessages(messageSet),
-			StreamingFunc:    opts.StreamingFunc,
-			Temperature:      opts.Temperature,
-			MaxTokens:        opts.MaxTokens,
-			N:                opts.N, // TODO: note, we are not returning multiple completions
-			FrequencyPenalty: opts.FrequencyPenalty,
-			PresencePenalty:  opts.PresencePenalty,
-
-			FunctionCallBehavior: openaiclient.FunctionCallBehavior(opts.FunctionCallBehavior),
-		}
-		for _, fn := range opts.Functions {
-			req.Functions = append(req.Functions, openaiclient.FunctionDefinition{
-				Name:        fn.Name,
-				Description: fn.Description,
-				Parameters:  fn.Parameters,
-			})
-		}
-		result, err := o.client.CreateChat(ctx, req)
+		result, err := o.client.CreateChat(ctx, chatRequest(messageSet, opts))
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 		if len(result.Choices) == 0 {
+			if handler != nil {
+				handler.HandleLLMError(ctx, ErrEmptyResponse)
+			}
 			return nil, ErrEmptyResponse
 		}
-		generationInfo := make(map[string]any, reflect.ValueOf(result.Usage).NumField())
-		generationInfo["CompletionTokens"] = result.Usage.CompletionTokens
-		generationInfo["PromptTokens"] = result.Usage.PromptTokens
-		generationInfo["TotalTokens"] = result.Usage.TotalTokens
-		msg := &schema.AIChatMessage{
-			Content: result.Choices[0].Message.Content,
+		usage := &llms.Usage{
+			Model:            result.Model,
+			PromptTokens:     int(result.Usage.PromptTokens),
+			CompletionTokens: int(result.Usage.CompletionTokens),
+			TotalTokens:      int(result.Usage.TotalTokens),
 		}
-		if result.Choices[0].FinishReason == "function_call" {
-			msg.FunctionCall = &schema.FunctionCall{
-				Name:      result.Choices[0].Message.FunctionCall.Name,
-				Arguments: result.Choices[0].Message.FunctionCall.Arguments,
-			}
+		for _, choice := range result.Choices {
+			generationInfo := make(map[string]any, reflect.ValueOf(result.Usage).NumField()+1)
+			generationInfo["CompletionTokens"] = result.Usage.CompletionTokens
+			generationInfo["PromptTokens"] = result.Usage.PromptTokens
+			generationInfo["TotalTokens"] = result.Usage.TotalTokens
+			generationInfo["FinishReason"] = choice.FinishReason
+			msg := clientMessageToMessage(choice.Message)
+			generations = append(generations, &llms.Generation{
+				Message:        msg,
+				Text:           msg.Content,
+				GenerationInfo: generationInfo,
+				Usage:          usage,
+			})
 		}
-		generations = append(generations, &llms.Generation{
-			Message:        msg,
-			Text:           msg.Content,
-			GenerationInfo: generationInfo,
-		})
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 
 	return generations, nil
 }
 
+func chatRequest(messages []schema.ChatMessage, opts llms.CallOptions) *openaiclient.ChatRequest {
+	req := &openaiclient.ChatRequest{
+		Model:            opts.Model,
+		StopWords:        opts.StopWords,
+		Messages:         messagesToClientMessages(messages),
+		StreamingFunc:    opts.StreamingFunc,
+		Temperature:      opts.Temperature,
+		MaxTokens:        opts.MaxTokens,
+		N:                opts.N,
+		FrequencyPenalty: opts.FrequencyPenalty,
+		PresencePenalty:  opts.PresencePenalty,
+	}
+	if opts.JSONMode {
+		req.ResponseFormat = &openaiclient.ResponseFormat{Type: "json_object"}
+	}
+	for _, fn := range opts.Functions {
+		req.Functions = append(req.Functions, openaiclient.FunctionDefinition{
+			Name:        fn.Name,
+			Description: fn.Description,
+			Parameters:  fn.Parameters,
+		})
+	}
+	if len(req.Functions) > 0 {
+		req.FunctionCallBehavior = openaiclient.FunctionCallBehavior(opts.FunctionCallBehavior)
+	}
+	for _, tool := range opts.Tools {
+		clientTool := openaiclient.Tool{Type: tool.Type}
+		if tool.Function != nil {
+			clientTool.Function = &openaiclient.FunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			}
+		}
+		req.Tools = append(req.Tools, clientTool)
+	}
+	if len(req.Tools) > 0 {
+		req.ToolChoice = openaiclient.ToolChoice(opts.ToolChoice)
+	}
+
+	return req
+}
+
+func clientMessageToMessage(m openaiclient.ChatMessage) *schema.AIChatMessage {
+	msg := &schema.AIChatMessage{
+		Content: m.Content,
+	}
+	if m.FunctionCall != nil {
+		msg.FunctionCall = &schema.FunctionCall{
+			Name:      m.FunctionCall.Name,
+			Arguments: m.FunctionCall.Arguments,
+		}
+	}
+	for _, call := range m.ToolCalls {
+		toolCall := schema.ToolCall{ID: call.ID, Type: call.Type}
+		if call.Function != nil {
+			toolCall.FunctionCall = &schema.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			}
+		}
+		msg.ToolCalls = append(msg.ToolCalls, toolCall)
+	}
+
+	return msg
+}
+
 func (o *Chat) GetNumTokens(text string) int {
 	return llms.CountTokens(o.client.Model, text)
 }
@@ -171,10 +231,33 @@ func messagesToClientMessages(messages []schema.ChatMessage) []*openaiclient.Cha
 			msg.Role = "user"
 		case schema.ChatMessageTypeFunction:
 			msg.Role = "function"
+		case schema.ChatMessageTypeTool:
+			msg.Role = "tool"
 		}
 		if n, ok := m.(schema.Named); ok {
 			msg.Name = n.GetName()
 		}
+		if ai, ok := m.(schema.AIChatMessage); ok && ai.FunctionCall != nil {
+			msg.FunctionCall = &openaiclient.FunctionCall{
+				Name:      ai.FunctionCall.Name,
+				Arguments: ai.FunctionCall.Arguments,
+			}
+		}
+		if ai, ok := m.(schema.AIChatMessage); ok {
+			for _, call := range ai.ToolCalls {
+				toolCall := &openaiclient.ToolCall{ID: call.ID, Type: call.Type}
+				if call.FunctionCall != nil {
+					toolCall.Function = &openaiclient.FunctionCall{
+						Name:      call.FunctionCall.Name,
+						Arguments: call.FunctionCall.Arguments,
+					}
+				}
+				msg.ToolCalls = append(msg.ToolCalls, toolCall)
+			}
+		}
+		if tool, ok := m.(schema.ToolChatMessage); ok {
+			msg.ToolCallID = tool.ID
+		}
 		msgs[i] = msg
 	}
 
@@ -0,0 +1,173 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/llms/openai/internal/openaiclient"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChat returns a chat LLM talking to a stand-in of the chat completions
+// API that records the request bodies and answers with the handler.
+func newTestChat(t *testing.T, requests *[]map[string]any, handler func(w http.ResponseWriter)) *Chat {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+		handler(w)
+	}))
+	t.Cleanup(server.Close)
+
+	chat, err := NewChat(WithToken("test"), WithBaseURL(server.URL))
+	require.NoError(t, err)
+	return chat
+}
+
+func TestChatToolCalls(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{"choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant",
+			"content":null,"tool_calls":[
+			{"id":"call_1","type":"function","function":{"name":"weather","arguments":"{\"city\":\"Oslo\"}"}},
+			{"id":"call_2","type":"function","function":{"name":"weather","arguments":"{\"city\":\"Rome\"}"}}]}}]}`))
+	})
+
+	tools := []llms.Tool{{Type: "function", Function: &llms.FunctionDefinition{
+		Name:       "weather",
+		Parameters: map[string]any{"type": "object"},
+	}}}
+	msg, err := chat.Call(context.Background(), []schema.ChatMessage{
+		schema.HumanChatMessage{Content: "Weather in Oslo and Rome?"},
+		schema.AIChatMessage{ToolCalls: []schema.ToolCall{{
+			ID: "call_0", Type: "function", FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: "{}"},
+		}}},
+		schema.ToolChatMessage{ID: "call_0", Content: "missing city"},
+	}, llms.WithTools(tools), llms.WithForcedFunction("weather"))
+	require.NoError(t, err)
+
+	require.Equal(t, []schema.ToolCall{
+		{ID: "call_1", Type: "function", FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Oslo"}`}},
+		{ID: "call_2", Type: "function", FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+	}, msg.ToolCalls)
+
+	req := requests[0]
+	require.Equal(t, map[string]any{"type": "function", "function": map[string]any{"name": "weather"}}, req["tool_choice"])
+	require.NotContains(t, req, "function_call")
+	require.Len(t, req["tools"], 1)
+	messages, ok := req["messages"].([]any)
+	require.True(t, ok)
+	require.Equal(t, "call_0", messages[1].(map[string]any)["tool_calls"].([]any)[0].(map[string]any)["id"])
+	require.Equal(t, map[string]any{"role": "tool", "content": "missing city", "tool_call_id": "call_0"}, messages[2])
+}
+
+func TestChatFunctionsAndJSONMode(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant",
+			"content":"","function_call":{"name":"search","arguments":"{}"}}}]}`))
+	})
+
+	msg, err := chat.Call(context.Background(), []schema.ChatMessage{schema.HumanChatMessage{Content: "Search"}},
+		llms.WithFunctions([]llms.FunctionDefinition{{Name: "search"}}),
+		llms.WithForcedFunction("search"),
+		llms.WithJSONMode(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, &schema.FunctionCall{Name: "search", Arguments: "{}"}, msg.FunctionCall)
+
+	require.Equal(t, map[string]any{"name": "search"}, requests[0]["function_call"])
+	require.Equal(t, map[string]any{"type": "json_object"}, requests[0]["response_format"])
+	require.NotContains(t, requests[0], "tool_choice")
+}
+
+func TestChatChoices(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		_, _ = w.Write([]byte(`{"model":"gpt-3.5-turbo-0613","choices":[
+			{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"Hi"}},
+			{"index":1,"finish_reason":"stop","message":{"role":"assistant","content":"Hello"}}],
+			"usage":{"prompt_tokens":9,"completion_tokens":4,"total_tokens":13}}`))
+	})
+
+	generations, err := chat.Generate(context.Background(),
+		[][]schema.ChatMessage{{schema.HumanChatMessage{Content: "Greet me"}}}, llms.WithN(2))
+	require.NoError(t, err)
+	require.Len(t, generations, 2)
+	require.Equal(t, "Hi", generations[0].Text)
+	require.Equal(t, "Hello", generations[1].Text)
+	require.Equal(t, float64(2), requests[0]["n"])
+
+	// The choices share the usage of the call, which is counted once.
+	require.Same(t, generations[0].Usage, generations[1].Usage)
+	require.Equal(t, llms.NewUsage("gpt-3.5-turbo-0613", 9, 4), llms.TotalUsage(generations))
+}
+
+func TestChatStreamingToolCalls(t *testing.T) {
+	t.Parallel()
+
+	var requests []map[string]any
+	chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+		for _, chunk := range []string{
+			`{"choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[` +
+				`{"index":0,"id":"call_1","type":"function","function":{"name":"weather","arguments":""}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[` +
+				`{"index":1,"id":"call_2","type":"function","function":{"name":"time","arguments":"{}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Oslo\"}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	var chunks int
+	msg, err := chat.Call(context.Background(), []schema.ChatMessage{schema.HumanChatMessage{Content: "Weather?"}},
+		llms.WithTools([]llms.Tool{{Type: "function", Function: &llms.FunctionDefinition{Name: "weather"}}}),
+		llms.WithStreamingFunc(func(_ context.Context, _ []byte) error {
+			chunks++
+			return nil
+		}))
+	require.NoError(t, err)
+	require.Equal(t, 4, chunks)
+	require.Equal(t, true, requests[0]["stream"])
+	require.Equal(t, []schema.ToolCall{
+		{ID: "call_1", Type: "function", FunctionCall: &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Oslo"}`}},
+		{ID: "call_2", Type: "function", FunctionCall: &schema.FunctionCall{Name: "time", Arguments: "{}"}},
+	}, msg.ToolCalls)
+}
+
+func TestChatStreamingNegativeIndex(t *testing.T) {
+	t.Parallel()
+
+	for _, chunk := range []string{
+		`{"choices":[{"index":-1,"delta":{"content":"Hi"}}]}`,
+		`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":-1,"function":{"name":"weather"}}]}}]}`,
+	} {
+		chunk := chunk
+		var requests []map[string]any
+		chat := newTestChat(t, &requests, func(w http.ResponseWriter) {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		})
+
+		_, err := chat.Call(context.Background(), []schema.ChatMessage{schema.HumanChatMessage{Content: "Hi"}},
+			llms.WithStreamingFunc(func(context.Context, []byte) error { return nil }))
+		require.ErrorIs(t, err, openaiclient.ErrUnexpectedChoiceIndex)
+	}
+}
@@ -1,6 +1,9 @@
 package openai
 
-import "github.com/aresa7796/langchaingo/llms/openai/internal/openaiclient"
+import (
+	"github.com/aresa7796/langchaingo/llms/openai/internal/openaiclient"
+	"github.com/aresa7796/langchaingo/llms/retry"
+)
 
 const (
 	tokenEnvVarName        = "OPENAI_API_KEY"      //nolint:gosec
@@ -28,6 +31,7 @@ type options struct {
 	organization string
 	apiType      APIType
 	httpClient   openaiclient.Doer
+	retryPolicy  *retry.Policy
 
 	// required when APIType is APITypeAzure or APITypeAzureAD
 	apiVersion     string
@@ -99,3 +103,12 @@ func WithHTTPClient(client openaiclient.Doer) Option {
 		opts.httpClient = client
 	}
 }
+
+// WithRetry wraps the HTTP client with a retry.Doer for the policy. Rate
+// limited requests wait for the reset the x-ratelimit-* headers announce, and
+// server and network errors back off exponentially; see retry.Policy.
+func WithRetry(policy retry.Policy) Option {
+	return func(opts *options) {
+		opts.retryPolicy = &policy
+	}
+}
@@ -17,7 +17,7 @@ type CallOptions struct {
 	StopWords []string `json:"stop_words"`
 	// StreamingFunc is a function to be called for each chunk of a streaming response.
 	// Return an error to stop streaming early.
-	StreamingFunc func(ctx context.Context, chunk []byte) error
+	StreamingFunc func(ctx context.Context, chunk []byte) error `json:"-"`
 	// TopK is the number of tokens to consider for top-k sampling.
 	TopK int `json:"top_k"`
 	// TopP is the cumulative probability for top-p sampling.
@@ -44,6 +44,14 @@ type CallOptions struct {
 	// If a specific function should be invoked, use the format:
 	// `{"name": "my_function"}`
 	FunctionCallBehavior FunctionCallBehavior `json:"function_call"`
+
+	// Tools are the tools the model can call, used by models supporting the tool
+	// calling protocol instead of Functions.
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice is the tool the model must call.
+	ToolChoice ToolChoice `json:"tool_choice,omitempty"`
+	// JSONMode makes the model respond with a JSON object.
+	JSONMode bool `json:"json_mode,omitempty"`
 }
 
 // FunctionDefinition is a definition of a function that can be called by the model.
@@ -56,7 +64,8 @@ type FunctionDefinition struct {
 	Parameters any `json:"parameters"`
 }
 
-// FunctionCallBehavior is the behavior to use when calling functions.
+// FunctionCallBehavior is the behavior to use when calling functions. Values
+// other than the constants are the name of the function the model must call.
 type FunctionCallBehavior string
 
 const (
@@ -66,6 +75,27 @@ const (
 	FunctionCallBehaviorAuto FunctionCallBehavior = "auto"
 )
 
+// Tool is a tool the model can call.
+type Tool struct {
+	// Type is the type of the tool, "function".
+	Type string `json:"type"`
+	// Function is the definition of the function, if the tool is a function.
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// ToolChoice decides which tool the model calls. Values other than the
+// constants are the name of the function the model must call.
+type ToolChoice string
+
+const (
+	// ToolChoiceNone will not call any tools.
+	ToolChoiceNone ToolChoice = "none"
+	// ToolChoiceAuto lets the model decide whether to call tools.
+	ToolChoiceAuto ToolChoice = "auto"
+	// ToolChoiceRequired makes the model call at least one tool.
+	ToolChoiceRequired ToolChoice = "required"
+)
+
 // WithModel is an option for LLM.Call.
 func WithModel(model string) CallOption {
 	return func(o *CallOptions) {
@@ -184,3 +214,33 @@ func WithFunctions(functions []FunctionDefinition) CallOption {
 		o.Functions = functions
 	}
 }
+
+// WithTools will add an option to set the tools the model can call.
+func WithTools(tools []Tool) CallOption {
+	return func(o *CallOptions) {
+		o.Tools = tools
+	}
+}
+
+// WithToolChoice will add an option to set which tool the model calls.
+func WithToolChoice(choice ToolChoice) CallOption {
+	return func(o *CallOptions) {
+		o.ToolChoice = choice
+	}
+}
+
+// WithForcedFunction will add an option making the model call the function
+// with the name, using tools or functions, whichever the call uses.
+func WithForcedFunction(name string) CallOption {
+	return func(o *CallOptions) {
+		o.FunctionCallBehavior = FunctionCallBehavior(name)
+		o.ToolChoice = ToolChoice(name)
+	}
+}
+
+// WithJSONMode will add an option making the model respond with a JSON object.
+func WithJSONMode() CallOption {
+	return func(o *CallOptions) {
+		o.JSONMode = true
+	}
+}
@@ -0,0 +1,10 @@
+// Package ratelimit provides a client-side rate limiter for LLM calls.
+//
+// A Limiter enforces a requests-per-minute budget, a tokens-per-minute budget
+// and a max number of calls in flight. LLM and ChatLLM wrap any llms.LLM or
+// llms.ChatLLM and acquire from a Limiter before every call. Share one Limiter
+// between all wrappers that use the same API key so they share its quota.
+//
+// Tokens are estimated before a call with llms.CountTokens: the tokens of the
+// prompts plus the max tokens to generate, if set in the call options.
+package ratelimit
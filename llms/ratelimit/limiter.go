@@ -0,0 +1,191 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+)
+
+const _window = time.Minute
+
+var (
+	// ErrRateLimited is returned by a fail fast Limiter when the budget is used up.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrExceedsBudget is returned when a single call needs more requests or
+	// tokens than the per minute budget allows, so it can never be made.
+	ErrExceedsBudget = errors.New("call exceeds the per minute budget")
+)
+
+// usage is the usage of one acquisition in the sliding window.
+type usage struct {
+	time     time.Time
+	requests int
+	tokens   int
+}
+
+// Limiter enforces requests-per-minute, tokens-per-minute and in-flight limits.
+// It is safe for concurrent use.
+type Limiter struct {
+	opts options
+
+	mu       sync.Mutex
+	window   []usage
+	inFlight int
+	// released is closed and replaced when a call is released.
+	released chan struct{}
+	now      func() time.Time
+}
+
+// NewLimiter creates a new Limiter.
+func NewLimiter(opts ...Option) *Limiter {
+	o := options{tokenizerModel: _defaultTokenizerModel}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Limiter{
+		opts:     o,
+		released: make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// CountTokens estimates the number of tokens of the text.
+func (l *Limiter) CountTokens(text string) int {
+	return llms.CountTokens(l.opts.tokenizerModel, text)
+}
+
+// Acquire waits until a call of the number of requests and tokens fits in the
+// budget, and returns a function that must be called when the call is done.
+// A fail fast Limiter returns ErrRateLimited instead of waiting.
+func (l *Limiter) Acquire(ctx context.Context, requests, tokens int) (func(), error) {
+	if l.opts.requestsPerMinute > 0 && requests > l.opts.requestsPerMinute {
+		return nil, fmt.Errorf("%w: %d requests, budget %d", ErrExceedsBudget, requests, l.opts.requestsPerMinute)
+	}
+	if l.opts.tokensPerMinute > 0 && tokens > l.opts.tokensPerMinute {
+		return nil, fmt.Errorf("%w: %d tokens, budget %d", ErrExceedsBudget, tokens, l.opts.tokensPerMinute)
+	}
+
+	for {
+		wait, released, ok := l.tryAcquire(requests, tokens)
+		if ok {
+			var once sync.Once
+			return func() { once.Do(l.release) }, nil
+		}
+		if l.opts.failFast {
+			return nil, ErrRateLimited
+		}
+
+		if err := waitForRelease(ctx, wait, released); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForRelease waits until the wait is over, if not zero, or until a call is
+// released.
+func waitForRelease(ctx context.Context, wait time.Duration, released <-chan struct{}) error {
+	var timer <-chan time.Time
+	if wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		timer = t.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer:
+	case <-released:
+	}
+
+	return nil
+}
+
+// tryAcquire records the call if it fits in the budget. Otherwise it returns how
+// long to wait for the window to free up, zero if only the in-flight limit is
+// hit, and a channel closed when a call is released.
+func (l *Limiter) tryAcquire(requests, tokens int) (time.Duration, <-chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.expire(now)
+
+	var wait time.Duration
+	fits := true
+	if l.opts.maxInFlight > 0 && l.inFlight >= l.opts.maxInFlight {
+		fits = false
+	}
+	if l.opts.requestsPerMinute > 0 {
+		if d, ok := l.waitFor(now, requests, l.opts.requestsPerMinute, func(u usage) int { return u.requests }); !ok {
+			fits = false
+			wait = maxDuration(wait, d)
+		}
+	}
+	if l.opts.tokensPerMinute > 0 {
+		if d, ok := l.waitFor(now, tokens, l.opts.tokensPerMinute, func(u usage) int { return u.tokens }); !ok {
+			fits = false
+			wait = maxDuration(wait, d)
+		}
+	}
+
+	if !fits {
+		return wait, l.released, false
+	}
+
+	l.window = append(l.window, usage{time: now, requests: requests, tokens: tokens})
+	l.inFlight++
+
+	return 0, nil, true
+}
+
+// waitFor checks if amount more fits in the limit for the window. If not, it
+// returns how long until enough of the window expires.
+func (l *Limiter) waitFor(now time.Time, amount, limit int, get func(usage) int) (time.Duration, bool) {
+	used := 0
+	for _, u := range l.window {
+		used += get(u)
+	}
+	if used+amount <= limit {
+		return 0, true
+	}
+
+	for _, u := range l.window {
+		used -= get(u)
+		if used+amount <= limit {
+			return u.time.Add(_window).Sub(now), false
+		}
+	}
+
+	return _window, false
+}
+
+// expire removes the usage older than the window.
+func (l *Limiter) expire(now time.Time) {
+	i := 0
+	for i < len(l.window) && now.Sub(l.window[i].time) >= _window {
+		i++
+	}
+	l.window = l.window[i:]
+}
+
+func (l *Limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+func maxDuration(a, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
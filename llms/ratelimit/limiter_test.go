@@ -0,0 +1,161 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a clock that only moves when advanced.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestLimiter(opts ...Option) (*Limiter, *testClock) {
+	clock := &testClock{now: time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)}
+	l := NewLimiter(opts...)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestLimiterRequestsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(WithRequestsPerMinute(2), WithFailFast())
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		release, err := l.Acquire(ctx, 1, 0)
+		require.NoError(t, err)
+		release()
+	}
+	_, err := l.Acquire(ctx, 1, 0)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	clock.Advance(time.Minute)
+	release, err := l.Acquire(ctx, 1, 0)
+	require.NoError(t, err)
+	release()
+
+	// A call of more requests than the budget can never be made, so it fails
+	// instead of waiting forever.
+	blocking, _ := newTestLimiter(WithRequestsPerMinute(2))
+	_, err = blocking.Acquire(ctx, 3, 0)
+	require.ErrorIs(t, err, ErrExceedsBudget)
+}
+
+func TestLimiterTokensPerMinute(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(WithTokensPerMinute(100), WithFailFast())
+	ctx := context.Background()
+
+	release, err := l.Acquire(ctx, 1, 60)
+	require.NoError(t, err)
+	release()
+
+	clock.Advance(30 * time.Second)
+	_, err = l.Acquire(ctx, 1, 60)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	wait, _, ok := l.tryAcquire(1, 60)
+	require.False(t, ok)
+	require.Equal(t, 30*time.Second, wait)
+
+	clock.Advance(30 * time.Second)
+	release, err = l.Acquire(ctx, 1, 60)
+	require.NoError(t, err)
+	release()
+
+	_, err = l.Acquire(ctx, 1, 101)
+	require.ErrorIs(t, err, ErrExceedsBudget)
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	l, _ := newTestLimiter(WithMaxInFlight(1))
+	ctx := context.Background()
+
+	release, err := l.Acquire(ctx, 1, 0)
+	require.NoError(t, err)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(timeoutCtx, 1, 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := l.Acquire(ctx, 1, 0)
+		if err == nil {
+			release()
+		}
+		close(acquired)
+	}()
+
+	release()
+	release() // Releasing twice has no effect.
+	<-acquired
+	require.Equal(t, 0, l.inFlight)
+}
+
+// countingLLM counts the calls made to it.
+type countingLLM struct {
+	calls int
+}
+
+func (l *countingLLM) Call(_ context.Context, prompt string, _ ...llms.CallOption) (string, error) {
+	l.calls++
+	return prompt, nil
+}
+
+func (l *countingLLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	generations := make([]*llms.Generation, 0, len(prompts))
+	for _, prompt := range prompts {
+		text, err := l.Call(ctx, prompt, options...)
+		if err != nil {
+			return nil, err
+		}
+		generations = append(generations, &llms.Generation{Text: text})
+	}
+	return generations, nil
+}
+
+func TestLLM(t *testing.T) {
+	t.Parallel()
+
+	wrapped := &countingLLM{}
+	l, _ := newTestLimiter(WithRequestsPerMinute(2), WithFailFast())
+	llm := NewLLM(wrapped, l)
+	ctx := context.Background()
+
+	text, err := llm.Call(ctx, "hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", text)
+
+	_, err = llm.Generate(ctx, []string{"a", "b"})
+	require.ErrorIs(t, err, ErrRateLimited)
+	require.Equal(t, 1, wrapped.calls)
+
+	_, err = llm.Call(ctx, "hello", llms.WithMaxTokens(10))
+	require.NoError(t, err)
+	require.Equal(t, 2, wrapped.calls)
+}
@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/aresa7796/langchaingo/llms"
+	"github.com/aresa7796/langchaingo/schema"
+)
+
+// LLM is an llms.LLM that acquires from a Limiter before every call.
+type LLM struct {
+	llm     llms.LLM
+	limiter *Limiter
+}
+
+var (
+	_ llms.LLM           = (*LLM)(nil)
+	_ llms.LanguageModel = (*LLM)(nil)
+)
+
+// NewLLM wraps the llm with the limiter.
+func NewLLM(llm llms.LLM, limiter *Limiter) *LLM {
+	return &LLM{llm: llm, limiter: limiter}
+}
+
+// Call acquires from the limiter and calls the wrapped llm.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	release, err := l.limiter.Acquire(ctx, 1, l.estimateTokens([]string{prompt}, options))
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	return l.llm.Call(ctx, prompt, options...)
+}
+
+// Generate acquires from the limiter, counting every prompt as a request, and
+// calls the wrapped llm.
+func (l *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
+	release, err := l.limiter.Acquire(ctx, len(prompts), l.estimateTokens(prompts, options))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return l.llm.Generate(ctx, prompts, options...)
+}
+
+func (l *LLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GeneratePrompt(ctx, l, prompts, options...)
+}
+
+// GetNumTokens uses the wrapped llm if it is a language model, and the tokenizer
+// of the limiter otherwise.
+func (l *LLM) GetNumTokens(text string) int {
+	if lm, ok := l.llm.(llms.LanguageModel); ok {
+		return lm.GetNumTokens(text)
+	}
+	return l.limiter.CountTokens(text)
+}
+
+func (l *LLM) estimateTokens(prompts []string, options []llms.CallOption) int {
+	maxTokens := maxTokensOf(options)
+	tokens := 0
+	for _, prompt := range prompts {
+		tokens += l.limiter.CountTokens(prompt) + maxTokens
+	}
+	return tokens
+}
+
+// ChatLLM is an llms.ChatLLM that acquires from a Limiter before every call.
+type ChatLLM struct {
+	llm     llms.ChatLLM
+	limiter *Limiter
+}
+
+var (
+	_ llms.ChatLLM       = (*ChatLLM)(nil)
+	_ llms.LanguageModel = (*ChatLLM)(nil)
+)
+
+// NewChatLLM wraps the chat llm with the limiter.
+func NewChatLLM(llm llms.ChatLLM, limiter *Limiter) *ChatLLM {
+	return &ChatLLM{llm: llm, limiter: limiter}
+}
+
+// Call acquires from the limiter and calls the wrapped chat llm.
+func (l *ChatLLM) Call(ctx context.Context, messages []schema.ChatMessage, options ...llms.CallOption) (*schema.AIChatMessage, error) { //nolint:lll
+	release, err := l.limiter.Acquire(ctx, 1, l.estimateTokens([][]schema.ChatMessage{messages}, options))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return l.llm.Call(ctx, messages, options...)
+}
+
+// Generate acquires from the limiter, counting every message set as a request,
+// and calls the wrapped chat llm.
+func (l *ChatLLM) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { //nolint:lll
+	release, err := l.limiter.Acquire(ctx, len(messageSets), l.estimateTokens(messageSets, options))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return l.llm.Generate(ctx, messageSets, options...)
+}
+
+func (l *ChatLLM) GeneratePrompt(ctx context.Context, prompts []schema.PromptValue, options ...llms.CallOption) (llms.LLMResult, error) { //nolint:lll
+	return llms.GenerateChatPrompt(ctx, l, prompts, options...)
+}
+
+// GetNumTokens uses the wrapped chat llm if it is a language model, and the
+// tokenizer of the limiter otherwise.
+func (l *ChatLLM) GetNumTokens(text string) int {
+	if lm, ok := l.llm.(llms.LanguageModel); ok {
+		return lm.GetNumTokens(text)
+	}
+	return l.limiter.CountTokens(text)
+}
+
+func (l *ChatLLM) estimateTokens(messageSets [][]schema.ChatMessage, options []llms.CallOption) int {
+	maxTokens := maxTokensOf(options)
+	tokens := 0
+	for _, messages := range messageSets {
+		for _, message := range messages {
+			tokens += l.limiter.CountTokens(message.GetContent())
+		}
+		tokens += maxTokens
+	}
+	return tokens
+}
+
+func maxTokensOf(options []llms.CallOption) int {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts.MaxTokens
+}
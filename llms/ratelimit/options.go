@@ -0,0 +1,54 @@
+package ratelimit
+
+const _defaultTokenizerModel = "gpt-3.5-turbo"
+
+type options struct {
+	requestsPerMinute int
+	tokensPerMinute   int
+	maxInFlight       int
+	failFast          bool
+	tokenizerModel    string
+}
+
+// Option is an option for a Limiter.
+type Option func(*options)
+
+// WithRequestsPerMinute sets the max number of requests per minute. Zero, the
+// default, means no limit.
+func WithRequestsPerMinute(requestsPerMinute int) Option {
+	return func(o *options) {
+		o.requestsPerMinute = requestsPerMinute
+	}
+}
+
+// WithTokensPerMinute sets the max number of estimated tokens per minute. Zero,
+// the default, means no limit.
+func WithTokensPerMinute(tokensPerMinute int) Option {
+	return func(o *options) {
+		o.tokensPerMinute = tokensPerMinute
+	}
+}
+
+// WithMaxInFlight sets the max number of calls running at the same time. Zero,
+// the default, means no limit.
+func WithMaxInFlight(maxInFlight int) Option {
+	return func(o *options) {
+		o.maxInFlight = maxInFlight
+	}
+}
+
+// WithFailFast makes calls fail with ErrRateLimited instead of blocking when
+// the budget is used up.
+func WithFailFast() Option {
+	return func(o *options) {
+		o.failFast = true
+	}
+}
+
+// WithTokenizerModel sets the model whose tokenizer is used to estimate the
+// tokens of a call. The default is gpt-3.5-turbo.
+func WithTokenizerModel(model string) Option {
+	return func(o *options) {
+		o.tokenizerModel = model
+	}
+}
@@ -0,0 +1,9 @@
+// Package retry provides a HTTP Doer that retries failed requests to LLM
+// providers with exponential backoff and jitter.
+//
+// The Doer honours the Retry-After header and the x-ratelimit-* headers sent by
+// providers like OpenAI, and only retries errors that are likely to be
+// transient, like rate limits, server errors and network errors. It can be
+// used anywhere a provider client accepts a custom HTTP client, and the LLM
+// packages expose it through a WithRetry option.
+package retry
@@ -0,0 +1,305 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the default max number of attempts of a request,
+	// including the first one.
+	DefaultMaxAttempts = 3
+	// DefaultInitialBackoff is the default wait before the first retry.
+	DefaultInitialBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff is the default max wait between two attempts computed
+	// with backoff. Waits asked for by the server are not limited.
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultMultiplier is the default factor the backoff grows with.
+	DefaultMultiplier = 2
+	// DefaultJitter is the default fraction of the backoff that is randomized.
+	DefaultJitter = 0.2
+)
+
+// statusOverloaded is the status code some providers, like
+// Anthropic, use when they are overloaded.
+const statusOverloaded = 529
+
+// Doer performs a HTTP request.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Policy configures how requests are retried.
+type Policy struct {
+	// MaxAttempts is the max number of attempts of a request, including the
+	// first one.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the max wait between two attempts computed with backoff.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the backoff grows with after each attempt.
+	Multiplier float64
+	// Jitter is the fraction of the backoff that is randomized, between 0 and 1.
+	Jitter float64
+	// Retryable decides if a request is retried. If not set, IsRetryable is used.
+	Retryable func(resp *http.Response, err error) bool
+}
+
+// DefaultPolicy returns the default retry policy.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		Multiplier:     DefaultMultiplier,
+		Jitter:         DefaultJitter,
+		Retryable:      IsRetryable,
+	}
+}
+
+// Client is a Doer that retries requests according to a policy.
+type Client struct {
+	doer   Doer
+	policy Policy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+var _ Doer = (*Client)(nil)
+
+// NewDoer returns a Doer that performs requests with doer and retries them
+// according to the policy. If doer is nil, http.DefaultClient is used. Unset
+// fields of the policy use the defaults.
+func NewDoer(doer Doer, policy Policy) *Client {
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+
+	defaults := DefaultPolicy()
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	if policy.InitialBackoff <= 0 {
+		policy.InitialBackoff = defaults.InitialBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaults.MaxBackoff
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		policy.Jitter = defaults.Jitter
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = defaults.Retryable
+	}
+
+	return &Client{
+		doer:   doer,
+		policy: policy,
+		sleep:  sleep,
+	}
+}
+
+// Do performs the request and retries it while the policy allows it. The
+// response of the last attempt is returned as is, so callers handle failed
+// requests as if no retries were made. Requests with a body that can not be
+// read again, because GetBody is not set, are not retried.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	maxAttempts := c.policy.MaxAttempts
+	if n, ok := maxAttemptsFromContext(ctx); ok {
+		maxAttempts = n
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := requestForAttempt(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.doer.Do(attemptReq)
+		if attempt >= maxAttempts || !c.policy.Retryable(resp, err) {
+			return resp, err
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if hint, ok := RetryAfter(resp.Header, time.Now()); ok {
+				wait = hint
+			}
+			drainAndClose(resp.Body)
+		}
+
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the wait before the retry after the attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := float64(c.policy.InitialBackoff) * math.Pow(c.policy.Multiplier, float64(attempt-1))
+	backoff = math.Min(backoff, float64(c.policy.MaxBackoff))
+	backoff *= 1 + c.policy.Jitter*(2*rand.Float64()-1) //nolint:gosec
+
+	return time.Duration(backoff)
+}
+
+// IsRetryable reports whether a request that failed with the response or error
+// is worth retrying. Network errors, timeouts of the request, rate limits and
+// server errors are retried. Cancellation of the context is not. The
+// x-should-retry header, when sent by the server, takes precedence.
+func IsRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	if resp == nil {
+		return false
+	}
+
+	switch strings.ToLower(resp.Header.Get("x-should-retry")) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusConflict,
+		http.StatusTooEarly,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+		statusOverloaded:
+		return true
+	}
+
+	return false
+}
+
+// RetryAfter returns the wait asked for by the server in the headers of a
+// response. The Retry-After header, in seconds or as a HTTP date, is used
+// first. Otherwise the x-ratelimit-reset-requests and x-ratelimit-reset-tokens
+// headers are used for the limits that have no requests or tokens remaining.
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(seconds * float64(time.Second)), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+
+	var wait time.Duration
+	found := false
+	for _, limit := range []string{"requests", "tokens"} {
+		if header.Get("x-ratelimit-remaining-"+limit) != "0" {
+			continue
+		}
+		if reset, ok := parseReset(header.Get("x-ratelimit-reset-"+limit), now); ok {
+			found = true
+			if reset > wait {
+				wait = reset
+			}
+		}
+	}
+
+	return wait, found
+}
+
+// parseReset parses the reset of a rate limit, sent as a duration like "1s" or
+// "6m0s", as seconds, or as a RFC 3339 time.
+func parseReset(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return nonNegative(d), true
+	}
+	if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return nonNegative(t.Sub(now)), true
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// requestForAttempt returns the request to send for the attempt. Retries get a
+// clone of the request with a new body.
+func requestForAttempt(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+
+	return clone, nil
+}
+
+func drainAndClose(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, body)
+	body.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type maxAttemptsKey struct{}
+
+// WithMaxAttempts returns a context that sets the max number of attempts of the
+// requests made with it, overriding the policy for a single call.
+func WithMaxAttempts(ctx context.Context, maxAttempts int) context.Context {
+	return context.WithValue(ctx, maxAttemptsKey{}, maxAttempts)
+}
+
+func maxAttemptsFromContext(ctx context.Context) (int, bool) {
+	n, ok := ctx.Value(maxAttemptsKey{}).(int)
+	if !ok || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,205 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aresa7796/langchaingo/llms/retry"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlakyServer returns a server that answers the first failures requests with
+// the status and headers given, and then echoes the request body.
+func newFlakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts
+}
+
+func testPolicy() retry.Policy {
+	return retry.Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func post(t *testing.T, ctx context.Context, doer retry.Doer, url string) *http.Response { //nolint:revive
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader("hello"))
+	require.NoError(t, err)
+	resp, err := doer.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func TestDoerRetries(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := newFlakyServer(t, 2, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}})
+	resp := post(t, context.Background(), retry.NewDoer(nil, testPolicy()), server.URL)
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(body))
+	require.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestDoerGivesUp(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := newFlakyServer(t, 5, http.StatusServiceUnavailable, nil)
+	resp := post(t, context.Background(), retry.NewDoer(nil, testPolicy()), server.URL)
+
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestDoerDoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := newFlakyServer(t, 1, http.StatusBadRequest, nil)
+	resp := post(t, context.Background(), retry.NewDoer(nil, testPolicy()), server.URL)
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestDoerMaxAttemptsFromContext(t *testing.T) {
+	t.Parallel()
+
+	server, attempts := newFlakyServer(t, 5, http.StatusInternalServerError, nil)
+	ctx := retry.WithMaxAttempts(context.Background(), 1)
+	resp := post(t, ctx, retry.NewDoer(nil, testPolicy()), server.URL)
+
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestDoerContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newFlakyServer(t, 5, http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = retry.NewDoer(nil, testPolicy()).Do(req) //nolint:bodyclose
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	response := func(status int, header http.Header) *http.Response {
+		return &http.Response{StatusCode: status, Header: header}
+	}
+
+	require.True(t, retry.IsRetryable(response(http.StatusTooManyRequests, http.Header{}), nil))
+	require.True(t, retry.IsRetryable(response(http.StatusBadGateway, http.Header{}), nil))
+	require.True(t, retry.IsRetryable(response(529, http.Header{}), nil))
+	require.False(t, retry.IsRetryable(response(http.StatusUnauthorized, http.Header{}), nil))
+	require.False(t, retry.IsRetryable(response(http.StatusTooManyRequests, http.Header{"X-Should-Retry": {"false"}}), nil))
+	require.True(t, retry.IsRetryable(response(http.StatusBadRequest, http.Header{"X-Should-Retry": {"true"}}), nil))
+	require.True(t, retry.IsRetryable(nil, io.ErrUnexpectedEOF))
+	require.False(t, retry.IsRetryable(nil, context.Canceled))
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		header   http.Header
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "none", header: http.Header{}},
+		{name: "seconds", header: http.Header{"Retry-After": {"2"}}, expected: 2 * time.Second, ok: true},
+		{
+			name:     "http date",
+			header:   http.Header{"Retry-After": {now.Add(3 * time.Second).Format(http.TimeFormat)}},
+			expected: 3 * time.Second,
+			ok:       true,
+		},
+		{
+			name: "requests limit",
+			header: http.Header{
+				"X-Ratelimit-Remaining-Requests": {"0"},
+				"X-Ratelimit-Reset-Requests":     {"1.5s"},
+				"X-Ratelimit-Remaining-Tokens":   {"100"},
+				"X-Ratelimit-Reset-Tokens":       {"6m0s"},
+			},
+			expected: 1500 * time.Millisecond,
+			ok:       true,
+		},
+		{
+			name: "both limits",
+			header: http.Header{
+				"X-Ratelimit-Remaining-Requests": {"0"},
+				"X-Ratelimit-Reset-Requests":     {"1s"},
+				"X-Ratelimit-Remaining-Tokens":   {"0"},
+				"X-Ratelimit-Reset-Tokens":       {now.Add(time.Minute).Format(time.RFC3339)},
+			},
+			expected: time.Minute,
+			ok:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			wait, ok := retry.RetryAfter(tc.header, now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, wait)
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	err := fmt.Errorf("generate: %w", retry.NewStatusError(resp, "overloaded"))
+	require.EqualError(t, err, "generate: API returned unexpected status code: 503: overloaded")
+
+	var statusErr *retry.StatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	require.True(t, statusErr.Retryable())
+
+	errAPI := errors.New("completion API returned unexpected status code")
+	statusErr = retry.NewStatusError(&http.Response{StatusCode: http.StatusUnauthorized}, "")
+	statusErr.Err = errAPI
+	require.EqualError(t, statusErr, "completion API returned unexpected status code: 401")
+	require.ErrorIs(t, statusErr, errAPI)
+	require.False(t, statusErr.Retryable())
+}
@@ -0,0 +1,51 @@
+package retry
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// StatusError is returned by the provider clients when a request fails with an
+// unexpected status code. Use errors.As to get the status code of a failed
+// request and Retryable to classify it.
+type StatusError struct {
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Header is the header of the response.
+	Header http.Header
+	// Message is the error message sent by the provider, if any.
+	Message string
+	// Err is the error of the client the status error wraps, if any.
+	Err error
+}
+
+// NewStatusError returns a status error for the response with the message
+// sent by the provider.
+func NewStatusError(resp *http.Response, message string) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Message:    message,
+	}
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("API returned unexpected status code: %d", e.StatusCode)
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %d", e.Err.Error(), e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether the failed request is worth retrying, or falling
+// back on another provider for, according to IsRetryable.
+func (e *StatusError) Retryable() bool {
+	return IsRetryable(&http.Response{StatusCode: e.StatusCode, Header: e.Header}, nil)
+}
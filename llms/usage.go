@@ -0,0 +1,66 @@
+package llms
+
+// Usage is the number of tokens a model call consumed, as reported by the
+// provider.
+type Usage struct {
+	// Model is the name of the model that reported the usage.
+	Model string `json:"model,omitempty"`
+	// PromptTokens is the number of tokens in the prompt.
+	PromptTokens int `json:"prompt_tokens"`
+	// CompletionTokens is the number of generated tokens.
+	CompletionTokens int `json:"completion_tokens"`
+	// TotalTokens is the sum of the prompt and completion tokens.
+	TotalTokens int `json:"total_tokens"`
+}
+
+// NewUsage returns the usage of a call to the given model, filling in the
+// total from the prompt and completion tokens.
+func NewUsage(model string, promptTokens, completionTokens int) *Usage {
+	return &Usage{
+		Model:            model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+}
+
+// Add adds the token counts of other to u. A nil other is ignored.
+func (u *Usage) Add(other *Usage) {
+	if other == nil {
+		return
+	}
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+}
+
+// TotalUsage sums the usage of the given generations. Generations sharing the
+// same *Usage come from one call and are counted once. It returns nil if none
+// of the generations report usage. The model is kept only if all generations
+// agree on it.
+func TotalUsage(generations []*Generation) *Usage {
+	var total *Usage
+	seen := make(map[*Usage]bool)
+	for _, g := range generations {
+		if g == nil || g.Usage == nil || seen[g.Usage] {
+			continue
+		}
+		seen[g.Usage] = true
+		if total == nil {
+			total = &Usage{Model: g.Usage.Model}
+		} else if total.Model != g.Usage.Model {
+			total.Model = ""
+		}
+		total.Add(g.Usage)
+	}
+	return total
+}
+
+// NewLLMResult returns the result of a single generate call, with the usage of
+// its generations summed up.
+func NewLLMResult(generations []*Generation) LLMResult {
+	return LLMResult{
+		Generations: [][]*Generation{generations},
+		Usage:       TotalUsage(generations),
+	}
+}
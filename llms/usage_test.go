@@ -0,0 +1,20 @@
+package llms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotalUsage(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, TotalUsage([]*Generation{{Text: "a"}}))
+
+	shared := NewUsage("gpt-4", 10, 5)
+	require.Equal(t, &Usage{Model: "gpt-4", PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+		TotalUsage([]*Generation{{Usage: shared}, {Usage: shared}}))
+
+	require.Equal(t, &Usage{PromptTokens: 11, CompletionTokens: 7, TotalTokens: 18},
+		TotalUsage([]*Generation{{Usage: shared}, {Usage: NewUsage("claude-2.1", 1, 2)}, {}}))
+}
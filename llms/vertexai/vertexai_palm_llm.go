@@ -40,32 +40,51 @@ func (o *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOptio
 }
 
 func (o *LLM) Generate(ctx context.Context, prompts []string, options ...llms.CallOption) ([]*llms.Generation, error) {
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMStart(ctx, prompts)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, prompts)
 	}
 
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 	results, err := o.client.CreateCompletion(ctx, &vertexaiclient.CompletionRequest{
 		Prompts:     prompts,
 		MaxTokens:   opts.MaxTokens,
 		Temperature: opts.Temperature,
 	})
 	if err != nil {
+		if handler != nil {
+			handler.HandleLLMError(ctx, err)
+		}
 		return nil, err
 	}
 
 	generations := []*llms.Generation{}
-	for _, r := range results {
+	for i, r := range results {
+		// The PaLM text API does not stream, so each completion is sent to the
+		// streaming func as a single chunk.
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(r.Text)); err != nil {
+				if handler != nil {
+					handler.HandleLLMError(ctx, err)
+				}
+				return nil, err
+			}
+		}
 		generations = append(generations, &llms.Generation{
 			Text: r.Text,
+			// The prediction API does not report token counts, so the usage
+			// is estimated.
+			Usage: llms.NewUsage(vertexaiclient.TextModelName, o.GetNumTokens(prompts[i]), o.GetNumTokens(r.Text)),
 		})
 	}
 
-	if o.CallbacksHandler != nil {
-		o.CallbacksHandler.HandleLLMEnd(ctx, llms.LLMResult{Generations: [][]*llms.Generation{generations}})
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
 	}
 	return generations, nil
 }
@@ -3,6 +3,7 @@ package vertexai
 import (
 	"context"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/llms"
 	"github.com/aresa7796/langchaingo/llms/vertexai/internal/vertexaiclient"
 	"github.com/aresa7796/langchaingo/schema"
@@ -16,7 +17,8 @@ const (
 type ChatMessage = vertexaiclient.ChatMessage
 
 type Chat struct {
-	client *vertexaiclient.PaLMClient
+	CallbacksHandler callbacks.Handler
+	client           *vertexaiclient.PaLMClient
 }
 
 var (
@@ -36,15 +38,21 @@ func (o *Chat) Call(ctx context.Context, messages []schema.ChatMessage, options
 	return r[0].Message, nil
 }
 
-// Generate requests a chat response for each of the sets of messages.
+// Generate requests a chat response for each of the sets of messages. The PaLM
+// chat API does not stream, so with a streaming func each response is sent to
+// it as a single chunk.
 func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage, options ...llms.CallOption) ([]*llms.Generation, error) { // nolint: lll
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, o.CallbacksHandler)
+	if handler != nil {
+		handler.HandleLLMStart(ctx, getPromptsFromMessageSets(messageSets))
+	}
+
 	opts := llms.CallOptions{}
 	for _, opt := range options {
 		opt(&opts)
 	}
-	if opts.StreamingFunc != nil {
-		return nil, ErrNotImplemented
-	}
+	opts.StreamingFunc = callbacks.StreamingFunc(handler, opts.StreamingFunc)
 
 	generations := make([]*llms.Generation, 0, len(messageSets))
 	for _, messages := range messageSets {
@@ -54,19 +62,40 @@ func (o *Chat) Generate(ctx context.Context, messageSets [][]schema.ChatMessage,
 			Messages:    msgs,
 		})
 		if err != nil {
+			if handler != nil {
+				handler.HandleLLMError(ctx, err)
+			}
 			return nil, err
 		}
 		if len(result.Candidates) == 0 {
+			if handler != nil {
+				handler.HandleLLMError(ctx, ErrEmptyResponse)
+			}
 			return nil, ErrEmptyResponse
 		}
+		if opts.StreamingFunc != nil {
+			if err := opts.StreamingFunc(ctx, []byte(result.Candidates[0].Content)); err != nil {
+				if handler != nil {
+					handler.HandleLLMError(ctx, err)
+				}
+				return nil, err
+			}
+		}
 		generations = append(generations, &llms.Generation{
 			Message: &schema.AIChatMessage{
 				Content: result.Candidates[0].Content,
 			},
 			Text: result.Candidates[0].Content,
+			// The prediction API does not report token counts, so the usage
+			// is estimated.
+			Usage: llms.NewUsage(vertexaiclient.ChatModelName,
+				o.countMessageTokens(msgs), o.GetNumTokens(result.Candidates[0].Content)),
 		})
 	}
 
+	if handler != nil {
+		handler.HandleLLMEnd(ctx, llms.NewLLMResult(generations))
+	}
 	return generations, nil
 }
 
@@ -78,6 +107,28 @@ func (o *Chat) GetNumTokens(text string) int {
 	return llms.CountTokens(vertexaiclient.TextModelName, text)
 }
 
+// countMessageTokens estimates the number of tokens of the messages.
+func (o *Chat) countMessageTokens(messages []*vertexaiclient.ChatMessage) int {
+	tokens := 0
+	for _, m := range messages {
+		tokens += o.GetNumTokens(m.Content)
+	}
+	return tokens
+}
+
+func getPromptsFromMessageSets(messageSets [][]schema.ChatMessage) []string {
+	prompts := make([]string, 0, len(messageSets))
+	for i := 0; i < len(messageSets); i++ {
+		curPrompt := ""
+		for j := 0; j < len(messageSets[i]); j++ {
+			curPrompt += messageSets[i][j].GetContent()
+		}
+		prompts = append(prompts, curPrompt)
+	}
+
+	return prompts
+}
+
 func toClientChatMessage(messages []schema.ChatMessage) []*vertexaiclient.ChatMessage {
 	msgs := make([]*vertexaiclient.ChatMessage, len(messages))
 	for i, m := range messages {
@@ -94,7 +145,7 @@ func toClientChatMessage(messages []schema.ChatMessage) []*vertexaiclient.ChatMe
 			msg.Author = userAuthor
 		case schema.ChatMessageTypeGeneric:
 			msg.Author = userAuthor
-		case schema.ChatMessageTypeFunction:
+		case schema.ChatMessageTypeFunction, schema.ChatMessageTypeTool:
 			msg.Author = userAuthor
 		}
 		if n, ok := m.(schema.Named); ok {
@@ -24,6 +24,8 @@ const (
 	ChatMessageTypeGeneric ChatMessageType = "generic"
 	// ChatMessageTypeFunction is a message sent by a function.
 	ChatMessageTypeFunction ChatMessageType = "function"
+	// ChatMessageTypeTool is a message sent by a tool.
+	ChatMessageTypeTool ChatMessageType = "tool"
 )
 
 // ChatMessage represents a message in a chat.
@@ -46,6 +48,7 @@ var (
 	_ ChatMessage = SystemChatMessage{}
 	_ ChatMessage = GenericChatMessage{}
 	_ ChatMessage = FunctionChatMessage{}
+	_ ChatMessage = ToolChatMessage{}
 )
 
 // AIChatMessage is a message sent by an AI.
@@ -55,6 +58,10 @@ type AIChatMessage struct {
 
 	// FunctionCall represents the model choosing to call a function.
 	FunctionCall *FunctionCall `json:"function_call,omitempty"`
+
+	// ToolCalls are the tools the model chose to call. Models supporting parallel
+	// tool calls can call more than one tool at once.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 }
 
 func (m AIChatMessage) GetType() ChatMessageType { return ChatMessageTypeAI }
@@ -103,6 +110,26 @@ func (m FunctionChatMessage) GetType() ChatMessageType { return ChatMessageTypeF
 func (m FunctionChatMessage) GetContent() string       { return m.Content }
 func (m FunctionChatMessage) GetName() string          { return m.Name }
 
+// ToolCall is a call to a tool made by the model.
+type ToolCall struct {
+	// ID is the id of the call, used to match the result of the call to it.
+	ID string `json:"id"`
+	// Type is the type of the tool, "function".
+	Type string `json:"type"`
+	// FunctionCall is the name and arguments of the function to call.
+	FunctionCall *FunctionCall `json:"function,omitempty"`
+}
+
+// ToolChatMessage is a chat message representing the result of a tool call.
+type ToolChatMessage struct {
+	// ID is the id of the tool call this message is the result of.
+	ID      string `json:"tool_call_id"`
+	Content string `json:"content"`
+}
+
+func (m ToolChatMessage) GetType() ChatMessageType { return ChatMessageTypeTool }
+func (m ToolChatMessage) GetContent() string       { return m.Content }
+
 // ChatGeneration is the output of a single chat generation.
 type ChatGeneration struct {
 	Generation
@@ -131,6 +158,13 @@ func GetBufferString(messages []ChatMessage, humanPrefix string, aiPrefix string
 			}
 			msg = fmt.Sprintf("%s %s", msg, string(j))
 		}
+		if m, ok := m.(AIChatMessage); ok && len(m.ToolCalls) > 0 {
+			j, err := json.Marshal(m.ToolCalls)
+			if err != nil {
+				return "", err
+			}
+			msg = fmt.Sprintf("%s %s", msg, string(j))
+		}
 		result = append(result, msg)
 	}
 	return strings.Join(result, "\n"), nil
@@ -153,6 +187,8 @@ func getMessageRole(m ChatMessage, humanPrefix, aiPrefix string) (string, error)
 		role = cgm.Role
 	case ChatMessageTypeFunction:
 		role = "Function"
+	case ChatMessageTypeTool:
+		role = "Tool"
 	default:
 		return "", ErrUnexpectedChatMessageType
 	}
@@ -31,19 +31,11 @@ func (c Calculator) Name() string {
 // string. If the evaluator errors the error is given in the result to give the
 // agent the ability to retry.
 func (c Calculator) Call(ctx context.Context, input string) (string, error) {
-	if c.CallbacksHandler != nil {
-		c.CallbacksHandler.HandleToolStart(ctx, input)
-	}
-
-	v, err := starlark.Eval(&starlark.Thread{Name: "main"}, "input", input, math.Module.Members)
-	if err != nil {
-		return fmt.Sprintf("error from evaluator: %s", err.Error()), nil //nolint:nilerr
-	}
-	result := v.String()
-
-	if c.CallbacksHandler != nil {
-		c.CallbacksHandler.HandleToolEnd(ctx, result)
-	}
-
-	return result, nil
+	return RunWithCallbacks(ctx, c.Name(), c.CallbacksHandler, input, func(context.Context) (string, error) {
+		v, err := starlark.Eval(&starlark.Thread{Name: "main"}, "input", input, math.Module.Members)
+		if err != nil {
+			return fmt.Sprintf("error from evaluator: %s", err.Error()), nil //nolint:nilerr
+		}
+		return v.String(), nil
+	})
 }
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+)
+
+// toolRunKey is the context key of the name of the tool run a context is in.
+type toolRunKey struct{}
+
+// RunWithCallbacks runs call as a run of the tool with the name, reporting its
+// start with the input, and its end or error, to the handler and the handlers of
+// the context. Tools call it with their own callbacks handler, and the agent
+// executor for each tool it runs. A tool called within the run the executor
+// started for it reports to its own handler only, so the handlers of the
+// context get each run once.
+func RunWithCallbacks(
+	ctx context.Context,
+	name string,
+	handler callbacks.Handler,
+	input string,
+	call func(ctx context.Context) (string, error),
+) (string, error) {
+	if running, _ := ctx.Value(toolRunKey{}).(string); running != name {
+		ctx = context.WithValue(callbacks.StartNamedRun(ctx, name), toolRunKey{}, name)
+		handler = callbacks.HandlerFor(ctx, handler)
+	}
+	if handler != nil {
+		handler.HandleToolStart(ctx, input)
+	}
+
+	result, err := call(ctx)
+	if err != nil {
+		if handler != nil {
+			handler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if handler != nil {
+		handler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
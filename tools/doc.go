@@ -1,2 +1,10 @@
 // Package tools defines a standard interface for tools to be used by agents.
+//
+// Tools that take structured arguments can implement StructuredTool to describe
+// their arguments with a JSON schema. NewStructured creates such a tool from a
+// function taking typed input.
+//
+// RunWithCallbacks reports a call of a tool as a tool run to callbacks
+// handlers. The agent executor reports every tool it runs this way, so custom
+// tools only need it to report calls made outside of an agent.
 package tools
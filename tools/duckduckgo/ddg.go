@@ -43,21 +43,15 @@ func (t Tool) Description() string {
 
 // Call performs the search and return the result.
 func (t Tool) Call(ctx context.Context, input string) (string, error) {
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolStart(ctx, input)
-	}
-
-	result, err := t.client.Search(ctx, input)
-	if err != nil {
-		if errors.Is(err, internal.ErrNoGoodResult) {
-			return "No good DuckDuckGo Search Results was found", nil
-		}
-		return "", err
-	}
-
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolEnd(ctx, result)
-	}
-
-	return result, nil
+	return tools.RunWithCallbacks(ctx, t.Name(), t.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			result, err := t.client.Search(ctx, input)
+			if errors.Is(err, internal.ErrNoGoodResult) {
+				return "No good DuckDuckGo Search Results was found", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return result, nil
+		})
 }
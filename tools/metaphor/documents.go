@@ -7,14 +7,16 @@ import (
 	"os"
 	"strings"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/metaphorsystems/metaphor-go"
 )
 
 // Documents defines a tool implementation for the Metaphor Web scrapper.
 type Documents struct {
-	client  *metaphor.Client
-	options []metaphor.ClientOptions
+	CallbacksHandler callbacks.Handler
+	client           *metaphor.Client
+	options          []metaphor.ClientOptions
 }
 
 var _ tools.Tool = &Documents{}
@@ -72,6 +74,14 @@ func (tool *Documents) Description() string {
 //
 // It returns a string which represents the formatted contents and an error if any.
 func (tool *Documents) Call(ctx context.Context, input string) (string, error) {
+	return tools.RunWithCallbacks(ctx, tool.Name(), tool.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return tool.getContents(ctx, input)
+		})
+}
+
+// getContents extracts the contents of the pages with the IDs of the input.
+func (tool *Documents) getContents(ctx context.Context, input string) (string, error) {
 	ids := strings.Split(input, ",")
 	for i, id := range ids {
 		ids[i] = strings.TrimSpace(id)
@@ -7,14 +7,16 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/metaphorsystems/metaphor-go"
 )
 
 // LinksSearch defines a tool implementation for the Metaphor Find Similar Links.
 type LinksSearch struct {
-	client  *metaphor.Client
-	options []metaphor.ClientOptions
+	CallbacksHandler callbacks.Handler
+	client           *metaphor.Client
+	options          []metaphor.ClientOptions
 }
 
 var _ tools.Tool = &LinksSearch{}
@@ -70,6 +72,14 @@ func (tool *LinksSearch) Description() string {
 // input - the string input used to find similar links, i.e. the url.
 // Returns a string containing the formatted links and an error if any occurred.
 func (tool *LinksSearch) Call(ctx context.Context, input string) (string, error) {
+	return tools.RunWithCallbacks(ctx, tool.Name(), tool.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return tool.findSimilar(ctx, input)
+		})
+}
+
+// findSimilar finds the links similar to the input.
+func (tool *LinksSearch) findSimilar(ctx context.Context, input string) (string, error) {
 	links, err := tool.client.FindSimilar(ctx, input, tool.options...)
 	if err != nil {
 		if errors.Is(err, metaphor.ErrNoLinksFound) {
@@ -9,15 +9,18 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/jsonschema"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/metaphorsystems/metaphor-go"
 )
 
-var _ tools.Tool = &API{}
+var _ tools.StructuredTool = &API{}
 
 // API defines a tool implementation for the Metaphor API.
 type API struct {
-	client *metaphor.Client
+	CallbacksHandler callbacks.Handler
+	client           *metaphor.Client
 }
 
 // ToolInput defines a struct the tool expects as input.
@@ -164,16 +167,75 @@ func (tool *API) Description() string {
 // The function returns the result of the respective operation or an empty string and nil
 // if the Operation is not supported.
 func (tool *API) Call(ctx context.Context, input string) (string, error) {
-	var toolInput ToolInput
+	return tools.RunWithCallbacks(ctx, tool.Name(), tool.CallbacksHandler, input, func(ctx context.Context) (string, error) {
+		var toolInput ToolInput
 
-	re := regexp.MustCompile(`(?s)\{.*\}`)
-	jsonString := re.FindString(input)
+		re := regexp.MustCompile(`(?s)\{.*\}`)
+		jsonString := re.FindString(input)
 
-	err := json.Unmarshal([]byte(jsonString), &toolInput)
+		err := json.Unmarshal([]byte(jsonString), &toolInput)
+		if err != nil {
+			return "", err
+		}
+
+		return tool.call(ctx, toolInput)
+	})
+}
+
+// Schema returns the JSON schema of the ToolInput the tool expects.
+func (tool *API) Schema() jsonschema.Definition {
+	domains := jsonschema.Definition{Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}}
+
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"operation": {
+				Type:        jsonschema.String,
+				Description: "Api call to be performed.",
+				Enum:        []string{"Search", "FindSimilar", "GetContents"},
+			},
+			"input": {
+				Type:        jsonschema.String,
+				Description: "Value of the search query or link, for search and findSimilar endpoints respectively.",
+			},
+			"reqOptions": {
+				Type:        jsonschema.Object,
+				Description: "Options of the API call. Omit the options that are not used.",
+				Properties: map[string]jsonschema.Definition{
+					"numResults":         {Type: jsonschema.Integer},
+					"includeDomains":     domains,
+					"excludeDomains":     domains,
+					"startCrawlDate":     {Type: jsonschema.String},
+					"endCrawlDate":       {Type: jsonschema.String},
+					"startPublishedDate": {Type: jsonschema.String},
+					"endPublishedDate":   {Type: jsonschema.String},
+					"useAutoprompt":      {Type: jsonschema.Boolean},
+					"type":               {Type: jsonschema.String},
+				},
+			},
+		},
+		Required: []string{"operation", "input"},
+	}
+}
+
+// CallStructured calls the tool with arguments already validated against Schema.
+func (tool *API) CallStructured(ctx context.Context, args map[string]any) (string, error) {
+	raw, err := json.Marshal(args)
 	if err != nil {
 		return "", err
 	}
 
+	return tools.RunWithCallbacks(ctx, tool.Name(), tool.CallbacksHandler, string(raw), func(ctx context.Context) (string, error) {
+		var toolInput ToolInput
+		if err := json.Unmarshal(raw, &toolInput); err != nil {
+			return "", fmt.Errorf("%w: %s", tools.ErrInvalidToolInput, err.Error())
+		}
+
+		return tool.call(ctx, toolInput)
+	})
+}
+
+func (tool *API) call(ctx context.Context, toolInput ToolInput) (string, error) {
 	switch toolInput.Operation {
 	case "Search":
 		return tool.performSearch(ctx, toolInput)
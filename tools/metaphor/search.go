@@ -7,14 +7,16 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/metaphorsystems/metaphor-go"
 )
 
 // Search defines a tool implementation for the Metaphor Search.
 type Search struct {
-	client  *metaphor.Client
-	options []metaphor.ClientOptions
+	CallbacksHandler callbacks.Handler
+	client           *metaphor.Client
+	options          []metaphor.ClientOptions
 }
 
 var _ tools.Tool = &Search{}
@@ -74,6 +76,14 @@ func (tool *Search) Description() string {
 // It takes a context.Context and a search query as string input as parameters.
 // It returns a string and an error.
 func (tool *Search) Call(ctx context.Context, input string) (string, error) {
+	return tools.RunWithCallbacks(ctx, tool.Name(), tool.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return tool.search(ctx, input)
+		})
+}
+
+// search searches the web for the input.
+func (tool *Search) search(ctx context.Context, input string) (string, error) {
 	response, err := tool.client.Search(ctx, input, tool.options...)
 	if err != nil {
 		if errors.Is(err, metaphor.ErrNoSearchResults) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/tools"
 	"github.com/gocolly/colly"
 )
@@ -22,11 +23,12 @@ const (
 var ErrScrapingFailed = errors.New("scraper could not read URL, or scraping is not allowed for provided URL")
 
 type Scraper struct {
-	MaxDepth  int
-	Parallels int
-	Delay     int64
-	Blacklist []string
-	Async     bool
+	MaxDepth         int
+	Parallels        int
+	Delay            int64
+	Blacklist        []string
+	Async            bool
+	CallbacksHandler callbacks.Handler
 }
 
 var _ tools.Tool = Scraper{}
@@ -87,9 +89,17 @@ func (s Scraper) Description() string {
 // The function takes a context.Context object for managing the execution
 // context and a string input representing the URL of the website to be scraped.
 // It returns a string containing the scraped data and an error if any.
+func (s Scraper) Call(ctx context.Context, input string) (string, error) {
+	return tools.RunWithCallbacks(ctx, s.Name(), s.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return s.scrape(ctx, input)
+		})
+}
+
+// scrape scrapes the website of the input URL.
 //
 //nolint:all
-func (s Scraper) Call(ctx context.Context, input string) (string, error) {
+func (s Scraper) scrape(ctx context.Context, input string) (string, error) {
 	_, err := url.ParseRequestURI(input)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", ErrScrapingFailed, err)
@@ -45,22 +45,15 @@ func (t Tool) Description() string {
 }
 
 func (t Tool) Call(ctx context.Context, input string) (string, error) {
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolStart(ctx, input)
-	}
-
-	result, err := t.client.Search(ctx, input)
-	if err != nil {
-		if errors.Is(err, internal.ErrNoGoodResult) {
-			return "No good Google Search Results was found", nil
-		}
-
-		return "", err
-	}
-
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolEnd(ctx, result)
-	}
-
-	return strings.Join(strings.Fields(result), " "), nil
+	return tools.RunWithCallbacks(ctx, t.Name(), t.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			result, err := t.client.Search(ctx, input)
+			if errors.Is(err, internal.ErrNoGoodResult) {
+				return "No good Google Search Results was found", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			return strings.Join(strings.Fields(result), " "), nil
+		})
 }
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/jsonschema"
+)
+
+// ErrInvalidToolInput is returned when the input to a structured tool is not a
+// JSON object matching the schema of the tool.
+var ErrInvalidToolInput = errors.New("invalid tool input")
+
+// StructuredTool is a tool that takes structured arguments described by a JSON
+// schema instead of a single string. Agents and the executor validate the
+// arguments against the schema before calling the tool.
+type StructuredTool interface {
+	Tool
+	// Schema returns the schema of the arguments of the tool.
+	Schema() jsonschema.Definition
+	// CallStructured calls the tool with the decoded arguments.
+	CallStructured(ctx context.Context, args map[string]any) (string, error)
+}
+
+// ParseStructuredInput decodes the input as a JSON object and validates it
+// against the schema. The returned error wraps ErrInvalidToolInput.
+func ParseStructuredInput(schema jsonschema.Definition, input string) (map[string]any, error) {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(input), &args); err != nil {
+		return nil, fmt.Errorf("%w: input is not a JSON object: %s", ErrInvalidToolInput, err.Error())
+	}
+
+	if err := jsonschema.Validate(schema, args); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToolInput, err.Error())
+	}
+
+	return args, nil
+}
+
+// Structured is a StructuredTool that decodes its arguments into a value of
+// type T before calling a function.
+type Structured[T any] struct {
+	CallbacksHandler callbacks.Handler
+
+	name        string
+	description string
+	schema      jsonschema.Definition
+	fn          func(context.Context, T) (string, error)
+}
+
+var _ StructuredTool = Structured[struct{}]{}
+
+// NewStructured creates a structured tool from a function taking typed input.
+// The arguments are validated against the schema and then decoded into T using
+// encoding/json.
+func NewStructured[T any](
+	name, description string,
+	schema jsonschema.Definition,
+	fn func(context.Context, T) (string, error),
+) Structured[T] {
+	return Structured[T]{
+		name:        name,
+		description: description,
+		schema:      schema,
+		fn:          fn,
+	}
+}
+
+// Name returns the name of the tool.
+func (s Structured[T]) Name() string {
+	return s.name
+}
+
+// Description returns the description of the tool.
+func (s Structured[T]) Description() string {
+	return s.description
+}
+
+// Schema returns the schema of the arguments of the tool.
+func (s Structured[T]) Schema() jsonschema.Definition {
+	return s.schema
+}
+
+// Call parses and validates the input as a JSON object and calls the tool.
+func (s Structured[T]) Call(ctx context.Context, input string) (string, error) {
+	return RunWithCallbacks(ctx, s.name, s.CallbacksHandler, input, func(ctx context.Context) (string, error) {
+		args, err := ParseStructuredInput(s.schema, input)
+		if err != nil {
+			return "", err
+		}
+
+		return s.callStructured(ctx, args)
+	})
+}
+
+// CallStructured decodes the arguments into T and calls the function of the tool.
+func (s Structured[T]) CallStructured(ctx context.Context, args map[string]any) (string, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	return RunWithCallbacks(ctx, s.name, s.CallbacksHandler, string(raw), func(ctx context.Context) (string, error) {
+		return s.callStructured(ctx, args)
+	})
+}
+
+func (s Structured[T]) callStructured(ctx context.Context, args map[string]any) (string, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	var input T
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidToolInput, err.Error())
+	}
+
+	return s.fn(ctx, input)
+}
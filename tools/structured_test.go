@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/callbacks"
+	"github.com/aresa7796/langchaingo/jsonschema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructured(t *testing.T) {
+	t.Parallel()
+
+	type weatherInput struct {
+		City string `json:"city"`
+		Days int    `json:"days"`
+	}
+
+	tool := NewStructured("weather", "Gets the weather forecast.", jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city": {Type: jsonschema.String},
+			"days": {Type: jsonschema.Integer},
+		},
+		Required: []string{"city"},
+	}, func(_ context.Context, input weatherInput) (string, error) {
+		return fmt.Sprintf("sunny in %s for %d days", input.City, input.Days), nil
+	})
+
+	result, err := tool.Call(context.Background(), `{"city": "Oslo", "days": 2}`)
+	require.NoError(t, err)
+	require.Equal(t, "sunny in Oslo for 2 days", result)
+
+	result, err = tool.CallStructured(context.Background(), map[string]any{"city": "Rome"})
+	require.NoError(t, err)
+	require.Equal(t, "sunny in Rome for 0 days", result)
+
+	_, err = tool.Call(context.Background(), `{"days": 2}`)
+	require.ErrorIs(t, err, ErrInvalidToolInput)
+
+	_, err = tool.Call(context.Background(), "Oslo")
+	require.ErrorIs(t, err, ErrInvalidToolInput)
+}
+
+// toolRecorder records the tool events it gets.
+type toolRecorder struct {
+	callbacks.SimpleHandler
+	events []string
+}
+
+func (r *toolRecorder) HandleToolStart(ctx context.Context, input string) {
+	r.events = append(r.events, "start "+callbacks.RunName(ctx)+" "+input)
+}
+
+func (r *toolRecorder) HandleToolEnd(_ context.Context, output string) {
+	r.events = append(r.events, "end "+output)
+}
+
+func (r *toolRecorder) HandleToolError(_ context.Context, err error) {
+	r.events = append(r.events, "error "+err.Error())
+}
+
+func TestStructuredCallbacks(t *testing.T) {
+	t.Parallel()
+
+	tool := NewStructured("echo", "Echoes the text.", jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{"text": {Type: jsonschema.String}},
+		Required:   []string{"text"},
+	}, func(_ context.Context, input struct {
+		Text string `json:"text"`
+	},
+	) (string, error) {
+		return input.Text, nil
+	})
+
+	recorder := &toolRecorder{}
+	ctx := callbacks.WithHandlers(context.Background(), recorder)
+
+	_, err := tool.Call(ctx, `{"text": "hi"}`)
+	require.NoError(t, err)
+	_, err = tool.CallStructured(ctx, map[string]any{"text": "bye"})
+	require.NoError(t, err)
+	_, err = tool.Call(ctx, "hi")
+	require.ErrorIs(t, err, ErrInvalidToolInput)
+
+	require.Equal(t, []string{
+		`start echo {"text": "hi"}`,
+		"end hi",
+		`start echo {"text":"bye"}`,
+		"end bye",
+		"start echo hi",
+	}, recorder.events[:5])
+	require.Len(t, recorder.events, 6)
+	require.Contains(t, recorder.events[5], "error ")
+}
+
+func TestCalculatorCallbacks(t *testing.T) {
+	t.Parallel()
+
+	recorder := &toolRecorder{}
+	calculator := Calculator{CallbacksHandler: recorder}
+
+	result, err := calculator.Call(context.Background(), "2 +")
+	require.NoError(t, err)
+	require.Contains(t, result, "error from evaluator")
+
+	// The evaluator error is the observation of a successful run.
+	require.Equal(t, []string{"start calculator 2 +", "end " + result}, recorder.events)
+}
@@ -59,10 +59,14 @@ func (t Tool) Description() string {
 // Call uses the wikipedia api to find the top search results for the input and returns
 // the first part of the documents combined.
 func (t Tool) Call(ctx context.Context, input string) (string, error) {
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolStart(ctx, input)
-	}
+	return tools.RunWithCallbacks(ctx, t.Name(), t.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return t.searchPages(ctx, input)
+		})
+}
 
+// searchPages returns the extracts of the pages found for the input.
+func (t Tool) searchPages(ctx context.Context, input string) (string, error) {
 	searchResult, err := search(ctx, t.TopK, input, t.LanguageCode, t.UserAgent)
 	if err != nil {
 		return "", err
@@ -91,9 +95,5 @@ func (t Tool) Call(ctx context.Context, input string) (string, error) {
 		result += page.Extract
 	}
 
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolEnd(ctx, result)
-	}
-
 	return result, nil
 }
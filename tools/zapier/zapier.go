@@ -79,20 +79,10 @@ func (t Tool) Description() string {
 }
 
 func (t Tool) Call(ctx context.Context, input string) (string, error) {
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolStart(ctx, input)
-	}
-
-	result, err := t.client.ExecuteAsString(ctx, t.actionID, input, t.params)
-	if err != nil {
-		return "", err
-	}
-
-	if t.CallbacksHandler != nil {
-		t.CallbacksHandler.HandleToolEnd(ctx, result)
-	}
-
-	return result, nil
+	return tools.RunWithCallbacks(ctx, t.Name(), t.CallbacksHandler, input,
+		func(ctx context.Context) (string, error) {
+			return t.client.ExecuteAsString(ctx, t.actionID, input, t.params)
+		})
 }
 
 func (t Tool) createDescription() string {
@@ -5,6 +5,9 @@ and querying documents as vector embeddings.
 The main components of this package are:
 
 - VectorStore interface: a common interface for saving and querying vector embeddings of documents.
+- Indexer interface: an optional interface for vector stores that can fetch, upsert and delete documents by ID.
+- ScoreSearcher interface: an optional interface for vector stores that return the scores of similarity search results.
+- MMRSearcher interface: an optional interface for vector stores that support maximal marginal relevance search.
 - Options: a set of options for similarity search and document addition.
 - Retriever: a retriever for vector stores that implements the schema.Retriever interface.
 
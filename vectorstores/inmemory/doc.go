@@ -0,0 +1,4 @@
+// Package inmemory contains an implementation of the vectorStore
+// interface that keeps the vectors in memory. The index can be saved to
+// and loaded from any io.Writer and io.Reader.
+package inmemory
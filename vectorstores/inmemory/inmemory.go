@@ -0,0 +1,510 @@
+package inmemory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/vectorstores"
+	"github.com/google/uuid"
+)
+
+const _snapshotVersion = 1
+
+var (
+	// ErrEmbedderWrongNumberVectors is returned when if the embedder returns a number
+	// of vectors that is not equal to the number of documents given.
+	ErrEmbedderWrongNumberVectors = errors.New(
+		"number of vectors from embedder does not match number of documents",
+	)
+	// ErrInvalidScoreThreshold is returned if the score threshold given to a
+	// search is not between 0 and 1.
+	ErrInvalidScoreThreshold = errors.New(
+		"score threshold must be between 0 and 1")
+	// ErrInvalidFilter is returned if the filters given are not a map[string]any.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrUnsupportedSnapshotVersion is returned when loading a snapshot written
+	// by an unknown version of the store.
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+// Store is a vector store keeping all vectors in memory. Similarity is measured
+// with cosine similarity. It is safe for concurrent use.
+type Store struct {
+	embedder  embeddings.Embedder
+	nameSpace string
+
+	mu      sync.RWMutex
+	entries []entry
+}
+
+var (
+	_ vectorstores.Indexer       = (*Store)(nil)
+	_ vectorstores.ScoreSearcher = (*Store)(nil)
+	_ vectorstores.MMRSearcher   = (*Store)(nil)
+)
+
+// entry is a single document and its vector in the index.
+type entry struct {
+	ID        string         `json:"id"`
+	NameSpace string         `json:"nameSpace"`
+	Content   string         `json:"content"`
+	Metadata  map[string]any `json:"metadata"`
+	Vector    []float64      `json:"vector"`
+}
+
+// snapshot is the format the index is saved in.
+type snapshot struct {
+	Version int     `json:"version"`
+	Entries []entry `json:"entries"`
+}
+
+// New creates a new empty Store with options. The embedder option must be set.
+func New(opts ...Option) (*Store, error) {
+	return applyClientOptions(opts...)
+}
+
+// AddDocuments creates vector embeddings from the documents using the embedder
+// and adds the vectors to the index.
+func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	_, err := s.AddDocumentsReturnIDs(ctx, docs, options...)
+	return err
+}
+
+// AddDocumentsReturnIDs adds the documents like AddDocuments and returns the
+// generated IDs of the documents.
+func (s *Store) AddDocumentsReturnIDs(
+	ctx context.Context,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, uuid.New().String())
+	}
+
+	if err := s.UpsertDocuments(ctx, ids, docs, options...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the embedder
+// and adds the vectors to the index with the given IDs. Documents in the name
+// space with the same IDs are replaced.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	opts := s.getOptions(options...)
+	nameSpace := s.getNameSpace(opts)
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := s.getEmbedder(opts).EmbedDocuments(ctx, texts)
+	if err != nil {
+		return err
+	}
+
+	if len(vectors) != len(docs) {
+		return ErrEmbedderWrongNumberVectors
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range docs {
+		e := entry{
+			ID:        ids[i],
+			NameSpace: nameSpace,
+			Content:   texts[i],
+			Metadata:  copyMetadata(docs[i].Metadata),
+			Vector:    vectors[i],
+		}
+
+		if j := s.indexOf(nameSpace, ids[i]); j >= 0 {
+			s.entries[j] = e
+			continue
+		}
+		s.entries = append(s.entries, e)
+	}
+
+	return nil
+}
+
+// GetDocuments returns the documents in the name space with the given IDs.
+func (s *Store) GetDocuments(
+	_ context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make(map[string]schema.Document, len(ids))
+	for _, id := range ids {
+		i := s.indexOf(nameSpace, id)
+		if i < 0 {
+			continue
+		}
+		docs[id] = schema.Document{
+			PageContent: s.entries[i].Content,
+			Metadata:    copyMetadata(s.entries[i].Metadata),
+		}
+	}
+
+	return docs, nil
+}
+
+// DeleteDocuments removes the documents in the name space with the given IDs.
+func (s *Store) DeleteDocuments(_ context.Context, ids []string, options ...vectorstores.Option) error {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	toDelete := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		toDelete[id] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteWhere(func(e entry) bool {
+		return e.NameSpace == nameSpace && toDelete[e.ID]
+	})
+
+	return nil
+}
+
+// DeleteByFilter removes the documents in the name space with metadata matching
+// the filter. The filter must be a map[string]any.
+func (s *Store) DeleteByFilter(_ context.Context, filter any, options ...vectorstores.Option) error {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	filters, ok := filter.(map[string]any)
+	if !ok || filters == nil {
+		return ErrInvalidFilter
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteWhere(func(e entry) bool {
+		return e.NameSpace == nameSpace && matchesFilters(e.Metadata, filters)
+	})
+
+	return nil
+}
+
+// SimilaritySearch creates a vector embedding from the query using the embedder
+// and returns the most similar documents in the name space. If the filters
+// option is set it must be a map[string]any, and only documents with metadata
+// equal to every key and value in the map are returned.
+func (s *Store) SimilaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	scored, err := s.SimilaritySearchWithScore(ctx, query, numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(scored))
+	for _, d := range scored {
+		docs = append(docs, d.Document)
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScore works like SimilaritySearch, but also returns the
+// cosine similarity between the query and each document.
+func (s *Store) SimilaritySearchWithScore(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]vectorstores.ScoredDocument, error) {
+	opts := s.getOptions(options...)
+
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.search(vector, numDocuments, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make([]vectorstores.ScoredDocument, 0, len(results))
+	for _, r := range results {
+		scored = append(scored, r.scored)
+	}
+
+	return scored, nil
+}
+
+// MaxMarginalRelevanceSearch returns k documents selected with maximal marginal
+// relevance from the fetchK documents most similar to the query.
+func (s *Store) MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	query string,
+	k, fetchK int,
+	lambda float64,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	vector, err := s.getEmbedder(opts).EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.search(vector, fetchK, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float64, 0, len(results))
+	for _, r := range results {
+		vectors = append(vectors, r.vector)
+	}
+
+	selected, err := vectorstores.MaximalMarginalRelevance(vector, vectors, k, lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(selected))
+	for _, i := range selected {
+		docs = append(docs, results[i].scored.Document)
+	}
+
+	return docs, nil
+}
+
+// searchResult is a document found in a search together with its vector.
+type searchResult struct {
+	scored vectorstores.ScoredDocument
+	vector []float64
+}
+
+// search returns the numDocuments entries in the name space most similar to the
+// vector, most similar first.
+func (s *Store) search(vector []float64, numDocuments int, opts vectorstores.Options) ([]searchResult, error) {
+	nameSpace := s.getNameSpace(opts)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]searchResult, 0)
+	for _, e := range s.entries {
+		if e.NameSpace != nameSpace || !matchesFilters(e.Metadata, filters) {
+			continue
+		}
+
+		score, err := embeddings.CosineSimilarity(vector, e.Vector)
+		if err != nil {
+			return nil, err
+		}
+
+		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
+		if scoreThreshold != 0 && score < scoreThreshold {
+			continue
+		}
+
+		results = append(results, searchResult{
+			scored: vectorstores.ScoredDocument{
+				Document: schema.Document{
+					PageContent: e.Content,
+					Metadata:    copyMetadata(e.Metadata),
+				},
+				Score: score,
+			},
+			vector: e.Vector,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].scored.Score > results[j].scored.Score
+	})
+
+	if numDocuments >= 0 && len(results) > numDocuments {
+		results = results[:numDocuments]
+	}
+
+	return results, nil
+}
+
+// DistanceStrategy returns the metric used for the scores, which is always
+// cosine similarity.
+func (s *Store) DistanceStrategy() vectorstores.DistanceStrategy {
+	return vectorstores.DistanceStrategyCosine
+}
+
+// Save writes a snapshot of the index to w. The snapshot can be restored
+// with Load.
+func (s *Store) Save(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(snapshot{
+		Version: _snapshotVersion,
+		Entries: s.entries,
+	})
+}
+
+// Load reads a snapshot written by Save from r and replaces the index of the
+// store with it.
+func (s *Store) Load(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return err
+	}
+
+	if snap.Version != _snapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snap.Version)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = snap.Entries
+
+	return nil
+}
+
+// indexOf returns the index of the entry with the id in the name space, or -1.
+// The caller must hold the lock.
+func (s *Store) indexOf(nameSpace, id string) int {
+	for i, e := range s.entries {
+		if e.NameSpace == nameSpace && e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// deleteWhere removes all entries for which del returns true. The caller must
+// hold the write lock.
+func (s *Store) deleteWhere(del func(e entry) bool) {
+	kept := s.entries[:0]
+	for _, e := range s.entries {
+		if !del(e) {
+			kept = append(kept, e)
+		}
+	}
+	s.entries = kept
+}
+
+func (s *Store) getNameSpace(opts vectorstores.Options) string {
+	if opts.NameSpace != "" {
+		return opts.NameSpace
+	}
+	return s.nameSpace
+}
+
+func (s *Store) getScoreThreshold(opts vectorstores.Options) (float64, error) {
+	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1 {
+		return 0, ErrInvalidScoreThreshold
+	}
+	return opts.ScoreThreshold, nil
+}
+
+func (s *Store) getFilters(opts vectorstores.Options) (map[string]any, error) {
+	if opts.Filters == nil {
+		return nil, nil
+	}
+
+	filters, ok := opts.Filters.(map[string]any)
+	if !ok {
+		return nil, ErrInvalidFilter
+	}
+	return filters, nil
+}
+
+func (s *Store) getEmbedder(opts vectorstores.Options) embeddings.Embedder { //nolint:ireturn
+	if opts.Embedder != nil {
+		return opts.Embedder
+	}
+	return s.embedder
+}
+
+func (s *Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+func matchesFilters(metadata, filters map[string]any) bool {
+	for key, want := range filters {
+		got, ok := metadata[key]
+		if !ok || !valuesEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// valuesEqual compares two metadata values. Numbers are compared by value so
+// that an int filter matches a float64 loaded from a snapshot.
+func valuesEqual(a, b any) bool {
+	af, aIsNumber := toFloat64(a)
+	bf, bIsNumber := toFloat64(b)
+	if aIsNumber && bIsNumber {
+		return af == bf
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat64(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func copyMetadata(metadata map[string]any) map[string]any {
+	c := make(map[string]any, len(metadata))
+	for key, value := range metadata {
+		c[key] = value
+	}
+	return c
+}
@@ -0,0 +1,234 @@
+package inmemory_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/aresa7796/langchaingo/schema"
+	"github.com/aresa7796/langchaingo/vectorstores"
+	"github.com/aresa7796/langchaingo/vectorstores/inmemory"
+	"github.com/stretchr/testify/require"
+)
+
+// keywordEmbedder embeds a text as the number of times each keyword occurs in it.
+type keywordEmbedder struct {
+	keywords []string
+}
+
+func (e keywordEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float64, error) {
+	vectors := make([][]float64, 0, len(texts))
+	for _, text := range texts {
+		v, err := e.EmbedQuery(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, nil
+}
+
+func (e keywordEmbedder) EmbedQuery(_ context.Context, text string) ([]float64, error) {
+	v := make([]float64, len(e.keywords))
+	for i, keyword := range e.keywords {
+		v[i] = float64(strings.Count(strings.ToLower(text), keyword))
+	}
+	return v, nil
+}
+
+func newTestStore(t *testing.T, opts ...inmemory.Option) *inmemory.Store {
+	t.Helper()
+
+	e := keywordEmbedder{keywords: []string{"japan", "city", "food", "ireland"}}
+	s, err := inmemory.New(append([]inmemory.Option{inmemory.WithEmbedder(e)}, opts...)...)
+	require.NoError(t, err)
+
+	err = s.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo is a city in japan", Metadata: map[string]any{"country": "japan", "population": 14}},
+		{PageContent: "sushi is food from japan", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "dublin is a city in ireland", Metadata: map[string]any{"country": "ireland"}},
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	docs, err := s.SimilaritySearch(context.Background(), "japan city", 1)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo is a city in japan", docs[0].PageContent)
+	require.Equal(t, "japan", docs[0].Metadata["country"])
+
+	docs, err = s.SimilaritySearch(context.Background(), "japan city", 10)
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+}
+
+func TestInMemoryStoreWithScoreThreshold(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	docs, err := s.SimilaritySearch(context.Background(), "japan", 10, vectorstores.WithScoreThreshold(0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	_, err = s.SimilaritySearch(context.Background(), "japan", 10, vectorstores.WithScoreThreshold(1.8))
+	require.ErrorIs(t, err, inmemory.ErrInvalidScoreThreshold)
+}
+
+func TestInMemoryStoreWithFilters(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	docs, err := s.SimilaritySearch(context.Background(), "city", 10,
+		vectorstores.WithFilters(map[string]any{"country": "ireland"}))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "dublin is a city in ireland", docs[0].PageContent)
+
+	_, err = s.SimilaritySearch(context.Background(), "city", 10, vectorstores.WithFilters("country"))
+	require.ErrorIs(t, err, inmemory.ErrInvalidFilter)
+}
+
+func TestInMemoryStoreWithNameSpace(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	err := s.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "ramen is food from japan"},
+	}, vectorstores.WithNameSpace("other"))
+	require.NoError(t, err)
+
+	docs, err := s.SimilaritySearch(context.Background(), "food", 10, vectorstores.WithNameSpace("other"))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "ramen is food from japan", docs[0].PageContent)
+}
+
+func TestInMemoryStoreSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.Save(&buf))
+
+	restored, err := inmemory.New(inmemory.WithEmbedder(keywordEmbedder{
+		keywords: []string{"japan", "city", "food", "ireland"},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, restored.Load(&buf))
+
+	docs, err := restored.SimilaritySearch(context.Background(), "japan city", 1,
+		vectorstores.WithFilters(map[string]any{"population": 14}))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo is a city in japan", docs[0].PageContent)
+}
+
+func TestInMemoryStoreAsRetriever(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+
+	docs, err := vectorstores.ToRetriever(s, 1).GetRelevantDocuments(context.Background(), "sushi food")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "sushi is food from japan", docs[0].PageContent)
+}
+
+func TestInMemoryStoreIndexer(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	ids, err := s.AddDocumentsReturnIDs(ctx, []schema.Document{
+		{PageContent: "cork is a city in ireland", Metadata: map[string]any{"country": "ireland"}},
+		{PageContent: "osaka is a city in japan", Metadata: map[string]any{"country": "japan"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	docs, err := s.GetDocuments(ctx, append(ids, "missing"))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "cork is a city in ireland", docs[ids[0]].PageContent)
+
+	err = s.UpsertDocuments(ctx, ids[:1], []schema.Document{
+		{PageContent: "galway is a city in ireland", Metadata: map[string]any{"country": "ireland"}},
+	})
+	require.NoError(t, err)
+
+	docs, err = s.GetDocuments(ctx, ids[:1])
+	require.NoError(t, err)
+	require.Equal(t, "galway is a city in ireland", docs[ids[0]].PageContent)
+
+	err = s.UpsertDocuments(ctx, ids, []schema.Document{{PageContent: "too few"}})
+	require.ErrorIs(t, err, vectorstores.ErrIDsDocumentsMismatch)
+
+	require.NoError(t, s.DeleteDocuments(ctx, ids[1:]))
+	docs, err = s.GetDocuments(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	require.NoError(t, s.DeleteByFilter(ctx, map[string]any{"country": "ireland"}))
+	results, err := s.SimilaritySearch(ctx, "ireland city", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, doc := range results {
+		require.Equal(t, "japan", doc.Metadata["country"])
+	}
+
+	require.ErrorIs(t, s.DeleteByFilter(ctx, nil), inmemory.ErrInvalidFilter)
+}
+
+func TestInMemoryStoreSimilaritySearchWithScore(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+	require.Equal(t, vectorstores.DistanceStrategyCosine, s.DistanceStrategy())
+
+	scored, err := s.SimilaritySearchWithScore(context.Background(), "ireland", 2)
+	require.NoError(t, err)
+	require.Len(t, scored, 2)
+	require.Equal(t, "dublin is a city in ireland", scored[0].Document.PageContent)
+	require.InDelta(t, 1/math.Sqrt(2), scored[0].Score, 1e-9)
+	require.InDelta(t, 0, scored[1].Score, 1e-9)
+}
+
+func TestInMemoryStoreMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+
+	s := newTestStore(t)
+	err := s.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "kyoto is a city in japan"},
+	})
+	require.NoError(t, err)
+
+	docs, err := s.SimilaritySearch(context.Background(), "city in japan", 2)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, docs[1].PageContent, "city in japan")
+
+	docs, err = s.MaxMarginalRelevanceSearch(context.Background(), "city in japan", 2, 10, 0.3)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Contains(t, docs[0].PageContent, "city in japan")
+	require.NotContains(t, docs[1].PageContent, "city in japan")
+
+	retriever := vectorstores.ToRetriever(s, 2, vectorstores.WithMaxMarginalRelevance(10, 0.3))
+	retrieved, err := retriever.GetRelevantDocuments(context.Background(), "city in japan")
+	require.NoError(t, err)
+	require.Equal(t, docs, retrieved)
+}
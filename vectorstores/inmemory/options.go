@@ -0,0 +1,47 @@
+package inmemory
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+)
+
+const _defaultNameSpace = "default"
+
+// ErrInvalidOptions is returned when the options given are invalid.
+var ErrInvalidOptions = errors.New("invalid options")
+
+// Option is a function type that can be used to modify the store.
+type Option func(s *Store)
+
+// WithEmbedder is an option for setting the embedder to use. Must be set.
+func WithEmbedder(e embeddings.Embedder) Option {
+	return func(s *Store) {
+		s.embedder = e
+	}
+}
+
+// WithNameSpace is an option for setting the default nameSpace to add and query
+// the vectors from. If not set the name space "default" is used.
+func WithNameSpace(nameSpace string) Option {
+	return func(s *Store) {
+		s.nameSpace = nameSpace
+	}
+}
+
+func applyClientOptions(opts ...Option) (*Store, error) {
+	s := &Store{
+		nameSpace: _defaultNameSpace,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.embedder == nil {
+		return nil, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
+	}
+
+	return s, nil
+}
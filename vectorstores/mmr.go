@@ -0,0 +1,93 @@
+package vectorstores
+
+import (
+	"errors"
+	"math"
+
+	"github.com/aresa7796/langchaingo/embeddings"
+)
+
+const (
+	// DefaultMMRFetchK is the default number of documents fetched before
+	// maximal marginal relevance reranking.
+	DefaultMMRFetchK = 20
+	// DefaultMMRLambda is the default lambda used in maximal marginal relevance
+	// reranking.
+	DefaultMMRLambda = 0.5
+)
+
+// ErrInvalidLambda is returned if the lambda given to a maximal marginal relevance
+// search is not between 0 and 1.
+var ErrInvalidLambda = errors.New("lambda must be between 0 and 1")
+
+// MaximalMarginalRelevance selects k of the vectors that are similar to the query
+// vector while being diverse among themselves. Lambda decides the trade-off: 1
+// gives the most similar vectors, 0 the most diverse ones. The indices of the
+// selected vectors are returned in the order they were selected.
+func MaximalMarginalRelevance(query []float64, vectors [][]float64, k int, lambda float64) ([]int, error) {
+	if lambda < 0 || lambda > 1 {
+		return nil, ErrInvalidLambda
+	}
+
+	if k > len(vectors) {
+		k = len(vectors)
+	}
+	if k <= 0 {
+		return []int{}, nil
+	}
+
+	similarityToQuery := make([]float64, len(vectors))
+	for i, v := range vectors {
+		similarity, err := embeddings.CosineSimilarity(query, v)
+		if err != nil {
+			return nil, err
+		}
+		similarityToQuery[i] = similarity
+	}
+
+	// maxSimilarityToSelected holds for each vector the highest similarity to
+	// any of the vectors selected so far.
+	maxSimilarityToSelected := make([]float64, len(vectors))
+	for i := range maxSimilarityToSelected {
+		maxSimilarityToSelected[i] = math.Inf(-1)
+	}
+
+	selected := make([]int, 0, k)
+	isSelected := make([]bool, len(vectors))
+	for len(selected) < k {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range vectors {
+			if isSelected[i] {
+				continue
+			}
+
+			score := similarityToQuery[i]
+			if len(selected) > 0 {
+				score = lambda*similarityToQuery[i] - (1-lambda)*maxSimilarityToSelected[i]
+			}
+			// Vectors scoring NaN, like ones with NaN values, are selected last.
+			if math.IsNaN(score) {
+				score = math.Inf(-1)
+			}
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		selected = append(selected, best)
+		isSelected[best] = true
+
+		for i, v := range vectors {
+			if isSelected[i] {
+				continue
+			}
+			similarity, err := embeddings.CosineSimilarity(vectors[best], v)
+			if err != nil {
+				return nil, err
+			}
+			maxSimilarityToSelected[i] = math.Max(maxSimilarityToSelected[i], similarity)
+		}
+	}
+
+	return selected, nil
+}
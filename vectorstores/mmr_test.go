@@ -0,0 +1,47 @@
+package vectorstores
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaximalMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	query := []float64{1, 0.2}
+	vectors := [][]float64{
+		{1, 0},
+		{0.99, 0.01},
+		{0.7, 0.7},
+		{0, 1},
+	}
+
+	cases := []struct {
+		k        int
+		lambda   float64
+		expected []int
+	}{
+		{k: 2, lambda: 1, expected: []int{1, 0}},
+		{k: 2, lambda: 0.6, expected: []int{1, 2}},
+		{k: 2, lambda: 0, expected: []int{1, 3}},
+		{k: 10, lambda: 1, expected: []int{1, 0, 2, 3}},
+		{k: 0, lambda: 0.5, expected: []int{}},
+	}
+
+	for _, tc := range cases {
+		selected, err := MaximalMarginalRelevance(query, vectors, tc.k, tc.lambda)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, selected)
+	}
+
+	// Vectors scoring NaN do not make the selection fail, and come last.
+	nan := []float64{math.NaN(), 0}
+	selected, err := MaximalMarginalRelevance(query, [][]float64{nan, {1, 0}, nan}, 3, 0.5)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 0, 2}, selected)
+
+	_, err = MaximalMarginalRelevance(query, vectors, 2, 1.5)
+	require.ErrorIs(t, err, ErrInvalidLambda)
+}
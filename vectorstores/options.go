@@ -11,6 +11,12 @@ type Options struct {
 	ScoreThreshold float64
 	Filters        any
 	Embedder       embeddings.Embedder
+
+	// MaxMarginalRelevance makes a Retriever use maximal marginal relevance
+	// search, fetching FetchK documents and reranking them with Lambda.
+	MaxMarginalRelevance bool
+	FetchK               int
+	Lambda               float64
 }
 
 // WithNameSpace returns an Option for setting the name space.
@@ -44,3 +50,18 @@ func WithEmbedder(embedder embeddings.Embedder) Option {
 		o.Embedder = embedder
 	}
 }
+
+// WithMaxMarginalRelevance returns an Option for making a Retriever use maximal
+// marginal relevance search instead of similarity search. FetchK documents are
+// fetched and reranked with lambda, see MMRSearcher. If fetchK is not positive
+// DefaultMMRFetchK is used. The vector store must implement MMRSearcher.
+func WithMaxMarginalRelevance(fetchK int, lambda float64) Option {
+	return func(o *Options) {
+		if fetchK <= 0 {
+			fetchK = DefaultMMRFetchK
+		}
+		o.MaxMarginalRelevance = true
+		o.FetchK = fetchK
+		o.Lambda = lambda
+	}
+}
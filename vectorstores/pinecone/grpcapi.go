@@ -3,10 +3,11 @@ package pinecone
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 
 	"github.com/aresa7796/langchaingo/schema"
-	"github.com/google/uuid"
+	"github.com/aresa7796/langchaingo/vectorstores"
 	"github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -44,6 +45,7 @@ func (s Store) getGRPCConn(ctx context.Context) (*grpc.ClientConn, error) {
 
 func (s Store) grpcUpsert(
 	ctx context.Context,
+	ids []string,
 	vectors [][]float64,
 	metadatas []map[string]any,
 	nameSpace string,
@@ -58,7 +60,7 @@ func (s Store) grpcUpsert(
 		pineconeVectors = append(
 			pineconeVectors,
 			&pinecone_grpc.Vector{
-				Id:       uuid.New().String(),
+				Id:       ids[i],
 				Values:   float64ToFloat32(vectors[i]),
 				Metadata: metadataStruct,
 			},
@@ -78,7 +80,14 @@ func (s Store) grpcQuery(
 	vector []float64,
 	numDocs int,
 	nameSpace string,
-) ([]schema.Document, error) {
+	scoreThreshold float64,
+	filter any,
+) ([]vectorstores.ScoredDocument, [][]float64, error) {
+	filterStruct, err := grpcFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	queryResult, err := s.client.Query(
 		ctx,
 		&pinecone_grpc.QueryRequest{
@@ -86,35 +95,113 @@ func (s Store) grpcQuery(
 				{Values: float64ToFloat32(vector)},
 			},
 			TopK:          uint32(numDocs),
-			IncludeValues: false,
+			IncludeValues: true,
 			Namespace:     nameSpace,
+			Filter:        filterStruct,
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(queryResult.Results) == 0 {
-		return nil, ErrEmptyResponse
+		return nil, nil, ErrEmptyResponse
 	}
 
-	resultDocuments := make([]schema.Document, 0)
+	resultDocuments := make([]vectorstores.ScoredDocument, 0)
+	resultVectors := make([][]float64, 0)
 	for _, match := range queryResult.Results[0].Matches {
+		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
+		if scoreThreshold != 0 && float64(match.Score) < scoreThreshold {
+			continue
+		}
+
 		metadata := match.Metadata.AsMap()
 
+		pageContent, ok := metadata[s.textKey].(string)
+		if !ok {
+			return nil, nil, ErrMissingTextKey
+		}
+		delete(metadata, s.textKey)
+
+		resultDocuments = append(resultDocuments, vectorstores.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    metadata,
+			},
+			Score: float64(match.Score),
+		})
+		resultVectors = append(resultVectors, float32ToFloat64(match.Values))
+	}
+
+	return resultDocuments, resultVectors, nil
+}
+
+// grpcFilter converts a metadata filter to the struct of a grpc request. Filters
+// that are not maps are converted through their JSON encoding.
+func grpcFilter(filter any) (*structpb.Struct, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	m, ok := filter.(map[string]any)
+	if !ok {
+		raw, err := json.Marshal(filter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, err.Error())
+		}
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, err.Error())
+		}
+	}
+
+	filterStruct, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFilter, err.Error())
+	}
+
+	return filterStruct, nil
+}
+
+func (s Store) grpcDelete(ctx context.Context, ids []string, nameSpace string) error {
+	_, err := s.client.Delete(ctx, &pinecone_grpc.DeleteRequest{
+		Ids:       ids,
+		Namespace: nameSpace,
+	})
+
+	return err
+}
+
+func (s Store) grpcFetch(
+	ctx context.Context,
+	ids []string,
+	nameSpace string,
+) (map[string]schema.Document, error) {
+	fetchResult, err := s.client.Fetch(ctx, &pinecone_grpc.FetchRequest{
+		Ids:       ids,
+		Namespace: nameSpace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]schema.Document, len(fetchResult.Vectors))
+	for id, v := range fetchResult.Vectors {
+		metadata := v.Metadata.AsMap()
+
 		pageContent, ok := metadata[s.textKey].(string)
 		if !ok {
 			return nil, ErrMissingTextKey
 		}
 		delete(metadata, s.textKey)
 
-		resultDocuments = append(resultDocuments, schema.Document{
+		docs[id] = schema.Document{
 			PageContent: pageContent,
 			Metadata:    metadata,
-		})
+		}
 	}
 
-	return resultDocuments, nil
+	return docs, nil
 }
 
 func float64ToFloat32(input []float64) []float32 {
@@ -124,3 +211,11 @@ func float64ToFloat32(input []float64) []float32 {
 	}
 	return output
 }
+
+func float32ToFloat64(input []float32) []float64 {
+	output := make([]float64, len(input))
+	for i, v := range input {
+		output[i] = float64(v)
+	}
+	return output
+}
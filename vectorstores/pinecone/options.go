@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/vectorstores"
 )
 
 const (
@@ -74,6 +75,15 @@ func WithNameSpace(nameSpace string) Option {
 	}
 }
 
+// WithDistanceStrategy is an option for setting the metric the pinecone index was
+// created with. It is only used to report how scores should be read. If not set
+// the pinecone default, cosine, is assumed.
+func WithDistanceStrategy(strategy vectorstores.DistanceStrategy) Option {
+	return func(p *Store) {
+		p.distanceStrategy = strategy
+	}
+}
+
 // withGrpc is an option for using the grpc api instead of the rest api.
 func withGrpc() Option { // nolint: unused
 	return func(p *Store) {
@@ -83,7 +93,8 @@ func withGrpc() Option { // nolint: unused
 
 func applyClientOptions(opts ...Option) (Store, error) {
 	o := &Store{
-		textKey: _defaultTextKey,
+		textKey:          _defaultTextKey,
+		distanceStrategy: vectorstores.DistanceStrategyCosine,
 	}
 
 	for _, opt := range opts {
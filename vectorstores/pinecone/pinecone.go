@@ -7,6 +7,7 @@ import (
 	"github.com/aresa7796/langchaingo/embeddings"
 	"github.com/aresa7796/langchaingo/schema"
 	"github.com/aresa7796/langchaingo/vectorstores"
+	"github.com/google/uuid"
 	"github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"google.golang.org/grpc"
 )
@@ -24,6 +25,11 @@ var (
 	ErrEmptyResponse         = errors.New("empty response")
 	ErrInvalidScoreThreshold = errors.New(
 		"score threshold must be between 0 and 1")
+	// ErrInvalidFilter is returned when deleting by a filter that is nil, or
+	// when a filter can not be sent with grpc.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrFilterNotSupported is returned when deleting by filter using the grpc api.
+	ErrFilterNotSupported = errors.New("delete by filter not supported with grpc")
 )
 
 // Store is a wrapper around the pinecone rest API and grpc client.
@@ -39,9 +45,15 @@ type Store struct {
 	textKey     string
 	nameSpace   string
 	useGRPC     bool
+
+	distanceStrategy vectorstores.DistanceStrategy
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.Indexer       = Store{}
+	_ vectorstores.ScoreSearcher = Store{}
+	_ vectorstores.MMRSearcher   = Store{}
+)
 
 // New creates a new Store with options. Options for index name, environment, project name
 // and embedder must be set.
@@ -68,6 +80,41 @@ func New(ctx context.Context, opts ...Option) (Store, error) {
 // AddDocuments creates vector embeddings from the documents using the embedder
 // and upsert the vectors to the pinecone index.
 func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	_, err := s.AddDocumentsReturnIDs(ctx, docs, options...)
+	return err
+}
+
+// AddDocumentsReturnIDs adds the documents like AddDocuments and returns the
+// generated IDs of the vectors.
+func (s Store) AddDocumentsReturnIDs(
+	ctx context.Context,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, uuid.New().String())
+	}
+
+	if err := s.UpsertDocuments(ctx, ids, docs, options...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the embedder
+// and upsert the vectors to the pinecone index with the given IDs.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsDocumentsMismatch
+	}
+
 	opts := s.getOptions(options...)
 
 	nameSpace := s.getNameSpace(opts)
@@ -98,39 +145,153 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 	}
 
 	if s.useGRPC {
-		return s.grpcUpsert(ctx, vectors, metadatas, nameSpace)
+		return s.grpcUpsert(ctx, ids, vectors, metadatas, nameSpace)
 	}
 
-	return s.restUpsert(ctx, vectors, metadatas, nameSpace)
+	return s.restUpsert(ctx, ids, vectors, metadatas, nameSpace)
+}
+
+// GetDocuments fetches the vectors with the given IDs from the pinecone index.
+func (s Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	if s.useGRPC {
+		return s.grpcFetch(ctx, ids, nameSpace)
+	}
+
+	return s.restFetch(ctx, ids, nameSpace)
+}
+
+// DeleteDocuments deletes the vectors with the given IDs from the pinecone index.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	if s.useGRPC {
+		return s.grpcDelete(ctx, ids, nameSpace)
+	}
+
+	return s.restDelete(ctx, deletePayload{IDs: ids, Namespace: nameSpace})
+}
+
+// DeleteByFilter deletes the vectors matching the metadata filter from the
+// pinecone index. Deleting by filter is only supported by the rest api.
+// See https://docs.pinecone.io/docs/metadata-filtering
+func (s Store) DeleteByFilter(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	if filter == nil {
+		return ErrInvalidFilter
+	}
+
+	if s.useGRPC {
+		return ErrFilterNotSupported
+	}
+
+	return s.restDelete(ctx, deletePayload{Filter: filter, Namespace: nameSpace})
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
 // and queries to find the most similar documents.
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
-	opts := s.getOptions(options...)
+	scored, err := s.SimilaritySearchWithScore(ctx, query, numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
 
-	nameSpace := s.getNameSpace(opts)
+	docs := make([]schema.Document, 0, len(scored))
+	for _, d := range scored {
+		docs = append(docs, d.Document)
+	}
 
-	filters := s.getFilters(opts)
+	return docs, nil
+}
 
-	scoreThreshold, err := s.getScoreThreshold(opts)
+// SimilaritySearchWithScore works like SimilaritySearch, but also returns the
+// score pinecone gave each match.
+func (s Store) SimilaritySearchWithScore(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]vectorstores.ScoredDocument, error) { //nolint:lll
+	opts := s.getOptions(options...)
+
+	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
+	scored, _, err := s.query(ctx, vector, numDocuments, opts)
+	return scored, err
+}
+
+// MaxMarginalRelevanceSearch queries the fetchK most similar vectors and returns
+// k documents selected from them with maximal marginal relevance.
+func (s Store) MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	query string,
+	k, fetchK int,
+	lambda float64,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
+	scored, vectors, err := s.query(ctx, vector, fetchK, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := vectorstores.MaximalMarginalRelevance(vector, vectors, k, lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(selected))
+	for _, i := range selected {
+		docs = append(docs, scored[i].Document)
+	}
+
+	return docs, nil
+}
+
+// query returns the documents of the most similar vectors together with the
+// vectors themselves.
+func (s Store) query(
+	ctx context.Context,
+	vector []float64,
+	numDocuments int,
+	opts vectorstores.Options,
+) ([]vectorstores.ScoredDocument, [][]float64, error) {
+	nameSpace := s.getNameSpace(opts)
+
+	filters := s.getFilters(opts)
+
+	scoreThreshold, err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if s.useGRPC {
-		return s.grpcQuery(ctx, vector, numDocuments, nameSpace)
+		return s.grpcQuery(ctx, vector, numDocuments, nameSpace, scoreThreshold, filters)
 	}
 
 	return s.restQuery(ctx, vector, numDocuments, nameSpace, scoreThreshold,
 		filters)
 }
 
+// DistanceStrategy returns the metric of the pinecone index, as set with the
+// WithDistanceStrategy option.
+func (s Store) DistanceStrategy() vectorstores.DistanceStrategy {
+	return s.distanceStrategy
+}
+
 // Close closes the grpc connection.
 func (s Store) Close() error {
 	return s.grpcConn.Close()
@@ -609,3 +609,120 @@ func TestPineconeAsRetrieverWithMetadataFilters(t *testing.T) {
 
 	require.Contains(t, result, "purple", "expected black in purple")
 }
+
+func TestPineconeStoreRestIndexer(t *testing.T) {
+	t.Parallel()
+
+	environment, apiKey, indexName, projectName := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	store, err := pinecone.New(
+		context.Background(),
+		pinecone.WithAPIKey(apiKey),
+		pinecone.WithEnvironment(environment),
+		pinecone.WithIndexName(indexName),
+		pinecone.WithProjectName(projectName),
+		pinecone.WithEmbedder(e),
+		pinecone.WithNameSpace(uuid.New().String()),
+	)
+	require.NoError(t, err)
+
+	ids, err := store.AddDocumentsReturnIDs(context.Background(), []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "potato", Metadata: map[string]any{"country": "ireland"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	err = store.UpsertDocuments(context.Background(), ids[:1], []schema.Document{
+		{PageContent: "osaka", Metadata: map[string]any{"country": "japan"}},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.GetDocuments(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "osaka", docs[ids[0]].PageContent)
+
+	err = store.DeleteDocuments(context.Background(), ids[:1])
+	require.NoError(t, err)
+
+	err = store.DeleteByFilter(context.Background(), map[string]any{
+		"country": map[string]any{"$eq": "ireland"},
+	})
+	require.NoError(t, err)
+}
+
+func TestPineconeStoreRestSimilaritySearchWithScore(t *testing.T) {
+	t.Parallel()
+
+	environment, apiKey, indexName, projectName := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	storer, err := pinecone.New(
+		context.Background(),
+		pinecone.WithAPIKey(apiKey),
+		pinecone.WithEnvironment(environment),
+		pinecone.WithIndexName(indexName),
+		pinecone.WithProjectName(projectName),
+		pinecone.WithEmbedder(e),
+		pinecone.WithNameSpace(uuid.New().String()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, vectorstores.DistanceStrategyCosine, storer.DistanceStrategy())
+
+	err = storer.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	})
+	require.NoError(t, err)
+
+	docs, err := storer.SimilaritySearchWithScore(context.Background(), "japan", 2)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "tokyo", docs[0].Document.PageContent)
+	require.Greater(t, docs[0].Score, docs[1].Score)
+}
+
+func TestPineconeStoreRestMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+
+	environment, apiKey, indexName, projectName := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	storer, err := pinecone.New(
+		context.Background(),
+		pinecone.WithAPIKey(apiKey),
+		pinecone.WithEnvironment(environment),
+		pinecone.WithIndexName(indexName),
+		pinecone.WithProjectName(projectName),
+		pinecone.WithEmbedder(e),
+		pinecone.WithNameSpace(uuid.New().String()),
+	)
+	require.NoError(t, err)
+
+	err = storer.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Tokyo, Japan"},
+		{PageContent: "Osaka"},
+		{PageContent: "Potato"},
+	})
+	require.NoError(t, err)
+
+	docs, err := vectorstores.ToRetriever(storer, 2, vectorstores.WithMaxMarginalRelevance(4, 0.5)).
+		GetRelevantDocuments(context.Background(), "Tokyo")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "Tokyo", docs[0].PageContent)
+	require.NotEqual(t, "Tokyo, Japan", docs[1].PageContent)
+}
+
+func TestDeleteDocumentsWithoutIDs(t *testing.T) {
+	t.Parallel()
+
+	// No request is sent, so the store needs no connection.
+	require.NoError(t, pinecone.Store{}.DeleteDocuments(context.Background(), nil))
+}
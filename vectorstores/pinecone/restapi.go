@@ -10,7 +10,7 @@ import (
 	"net/url"
 
 	"github.com/aresa7796/langchaingo/schema"
-	"github.com/google/uuid"
+	"github.com/aresa7796/langchaingo/vectorstores"
 )
 
 // APIError is an error type returned if the status code from the rest
@@ -47,6 +47,7 @@ type upsertPayload struct {
 
 func (s Store) restUpsert(
 	ctx context.Context,
+	ids []string,
 	vectors [][]float64,
 	metadatas []map[string]any,
 	nameSpace string,
@@ -56,7 +57,7 @@ func (s Store) restUpsert(
 		v = append(v, vector{
 			Values:   vectors[i],
 			Metadata: metadatas[i],
-			ID:       uuid.New().String(),
+			ID:       ids[i],
 		})
 	}
 
@@ -118,7 +119,7 @@ func (s Store) restQuery(
 	nameSpace string,
 	scoreThreshold float64,
 	filter any,
-) ([]schema.Document, error) {
+) ([]vectorstores.ScoredDocument, [][]float64, error) {
 	payload := queryPayload{
 		IncludeValues:   true,
 		IncludeMetadata: true,
@@ -136,12 +137,12 @@ func (s Store) restQuery(
 		http.MethodPost,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer body.Close()
 
 	if statusCode != http.StatusOK {
-		return nil, newAPIError("querying index", body)
+		return nil, nil, newAPIError("querying index", body)
 	}
 
 	var response queriesResponse
@@ -149,31 +150,120 @@ func (s Store) restQuery(
 	decoder := json.NewDecoder(body)
 	err = decoder.Decode(&response)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(response.Matches) == 0 {
-		return nil, ErrEmptyResponse
+		return nil, nil, ErrEmptyResponse
 	}
 
-	docs := make([]schema.Document, 0, len(response.Matches))
+	docs := make([]vectorstores.ScoredDocument, 0, len(response.Matches))
+	vectors := make([][]float64, 0, len(response.Matches))
 	for _, match := range response.Matches {
 		pageContent, ok := match.Metadata[s.textKey].(string)
 		if !ok {
-			return nil, ErrMissingTextKey
+			return nil, nil, ErrMissingTextKey
 		}
 		delete(match.Metadata, s.textKey)
 
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    match.Metadata,
+		doc := vectorstores.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    match.Metadata,
+			},
+			Score: match.Score,
 		}
 
 		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
 		if scoreThreshold != 0 && match.Score >= scoreThreshold {
 			docs = append(docs, doc)
+			vectors = append(vectors, match.Values)
 		} else if scoreThreshold == 0 { // If scoreThreshold is 0, we return all matches.
 			docs = append(docs, doc)
+			vectors = append(vectors, match.Values)
+		}
+	}
+
+	return docs, vectors, nil
+}
+
+type deletePayload struct {
+	IDs       []string `json:"ids,omitempty"`
+	Filter    any      `json:"filter,omitempty"`
+	Namespace string   `json:"namespace"`
+}
+
+func (s Store) restDelete(ctx context.Context, payload deletePayload) error {
+	body, status, err := doRequest(
+		ctx,
+		payload,
+		getEndpoint(s.indexName, s.projectName, s.environment)+"/vectors/delete",
+		s.apiKey,
+		http.MethodPost,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if status == http.StatusOK {
+		return nil
+	}
+
+	return newAPIError("deleting vectors", body)
+}
+
+type fetchResponse struct {
+	Vectors   map[string]vector `json:"vectors"`
+	Namespace string            `json:"namespace"`
+}
+
+func (s Store) restFetch(
+	ctx context.Context,
+	ids []string,
+	nameSpace string,
+) (map[string]schema.Document, error) {
+	query := url.Values{}
+	for _, id := range ids {
+		query.Add("ids", id)
+	}
+	query.Set("namespace", nameSpace)
+
+	body, statusCode, err := doRequest(
+		ctx,
+		nil,
+		getEndpoint(s.indexName, s.projectName, s.environment)+"/vectors/fetch?"+query.Encode(),
+		s.apiKey,
+		http.MethodGet,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("fetching vectors", body)
+	}
+
+	var response fetchResponse
+
+	decoder := json.NewDecoder(body)
+	err = decoder.Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]schema.Document, len(response.Vectors))
+	for id, v := range response.Vectors {
+		pageContent, ok := v.Metadata[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(v.Metadata, s.textKey)
+
+		docs[id] = schema.Document{
+			PageContent: pageContent,
+			Metadata:    v.Metadata,
 		}
 	}
 
@@ -181,11 +271,14 @@ func (s Store) restQuery(
 }
 
 func doRequest(ctx context.Context, payload any, url, apiKey, method string) (io.ReadCloser, int, error) {
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, 0, err
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		body = bytes.NewReader(payloadBytes)
 	}
-	body := bytes.NewReader(payloadBytes)
 
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
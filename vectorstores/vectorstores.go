@@ -2,6 +2,7 @@ package vectorstores
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aresa7796/langchaingo/callbacks"
 	"github.com/aresa7796/langchaingo/schema"
@@ -14,6 +15,82 @@ type VectorStore interface {
 	SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) //nolint:lll
 }
 
+// ErrIDsDocumentsMismatch is returned when the number of ids given does not match
+// the number of documents given.
+var ErrIDsDocumentsMismatch = errors.New("number of ids does not match number of documents")
+
+// Indexer is an optional interface for vector stores that keep track of the IDs
+// of the stored documents, so that documents can be fetched, re-indexed and
+// removed after they have been added.
+type Indexer interface {
+	VectorStore
+	// AddDocumentsReturnIDs adds the documents like AddDocuments and returns the
+	// generated IDs of the documents in the same order as the documents.
+	AddDocumentsReturnIDs(ctx context.Context, docs []schema.Document, options ...Option) ([]string, error)
+	// UpsertDocuments adds the documents using the given IDs. Existing documents
+	// with the same IDs are replaced.
+	UpsertDocuments(ctx context.Context, ids []string, docs []schema.Document, options ...Option) error
+	// GetDocuments returns the documents with the given IDs, keyed by ID. IDs that
+	// are not found are left out of the result.
+	GetDocuments(ctx context.Context, ids []string, options ...Option) (map[string]schema.Document, error)
+	// DeleteDocuments removes the documents with the given IDs.
+	DeleteDocuments(ctx context.Context, ids []string, options ...Option) error
+	// DeleteByFilter removes all documents matching the filter. The format of
+	// the filter is the same as for the WithFilters option of the store, and
+	// the filter must not be nil.
+	DeleteByFilter(ctx context.Context, filter any, options ...Option) error
+}
+
+// DistanceStrategy is the metric a vector store uses to compare vectors. It
+// tells how the scores returned by SimilaritySearchWithScore should be read.
+type DistanceStrategy string
+
+const (
+	// DistanceStrategyCosine scores documents by cosine similarity. Higher scores
+	// are more similar.
+	DistanceStrategyCosine DistanceStrategy = "cosine"
+	// DistanceStrategyDotProduct scores documents by the dot product of the
+	// vectors. Higher scores are more similar.
+	DistanceStrategyDotProduct DistanceStrategy = "dot_product"
+	// DistanceStrategyEuclidean scores documents by the euclidean distance
+	// between the vectors. Lower scores are more similar.
+	DistanceStrategyEuclidean DistanceStrategy = "euclidean"
+)
+
+// ScoredDocument is a document returned from a similarity search together with
+// the score the vector store gave it.
+type ScoredDocument struct {
+	Document schema.Document
+	Score    float64
+}
+
+// ScoreSearcher is an optional interface for vector stores that can return the
+// scores of the documents found in a similarity search.
+type ScoreSearcher interface {
+	VectorStore
+	// SimilaritySearchWithScore works like SimilaritySearch, but returns the
+	// documents paired with their scores, most similar first.
+	SimilaritySearchWithScore(ctx context.Context, query string, numDocuments int, options ...Option) ([]ScoredDocument, error) //nolint:lll
+	// DistanceStrategy returns the metric the scores are computed with.
+	DistanceStrategy() DistanceStrategy
+}
+
+// ErrMMRNotSupported is returned by a retriever configured to use maximal marginal
+// relevance search if the vector store does not implement MMRSearcher.
+var ErrMMRNotSupported = errors.New("vector store does not support maximal marginal relevance search")
+
+// MMRSearcher is an optional interface for vector stores that support maximal
+// marginal relevance search.
+type MMRSearcher interface {
+	VectorStore
+	// MaxMarginalRelevanceSearch fetches the fetchK documents most similar to the
+	// query and returns k of them selected with maximal marginal relevance, so
+	// that the returned documents are both relevant and diverse. Lambda must be
+	// between 0 and 1, where 1 gives the most similar documents and 0 the most
+	// diverse ones.
+	MaxMarginalRelevanceSearch(ctx context.Context, query string, k, fetchK int, lambda float64, options ...Option) ([]schema.Document, error) //nolint:lll
+}
+
 // Retriever is a retriever for vector stores.
 type Retriever struct {
 	CallbacksHandler callbacks.Handler
@@ -26,24 +103,48 @@ var _ schema.Retriever = Retriever{}
 
 // GetRelevantDocuments returns documents using the vector store.
 func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
-	if r.CallbacksHandler != nil {
-		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	ctx = callbacks.StartRun(ctx)
+	handler := callbacks.HandlerFor(ctx, r.CallbacksHandler)
+	if handler != nil {
+		handler.HandleRetrieverStart(ctx, query)
 	}
 
-	docs, err := r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	docs, err := r.search(ctx, query)
 	if err != nil {
+		if handler != nil {
+			handler.HandleRetrieverError(ctx, err)
+		}
 		return nil, err
 	}
 
-	if r.CallbacksHandler != nil {
-		r.CallbacksHandler.HandleRetrieverEnd(ctx, docs)
+	if handler != nil {
+		handler.HandleRetrieverEnd(ctx, docs)
 	}
 
 	return docs, nil
 }
 
+func (r Retriever) search(ctx context.Context, query string) ([]schema.Document, error) {
+	opts := Options{}
+	for _, opt := range r.options {
+		opt(&opts)
+	}
+
+	if !opts.MaxMarginalRelevance {
+		return r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	}
+
+	mmrSearcher, ok := r.v.(MMRSearcher)
+	if !ok {
+		return nil, ErrMMRNotSupported
+	}
+
+	return mmrSearcher.MaxMarginalRelevanceSearch(ctx, query, r.numDocs, opts.FetchK, opts.Lambda, r.options...)
+}
+
 // ToRetriever takes a vector store and returns a retriever using the
-// vector store to retrieve documents.
+// vector store to retrieve documents. Pass the WithMaxMarginalRelevance option
+// to retrieve documents using maximal marginal relevance search.
 func ToRetriever(vectorStore VectorStore, numDocuments int, options ...Option) Retriever {
 	return Retriever{
 		v:       vectorStore,
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/aresa7796/langchaingo/embeddings"
+	"github.com/aresa7796/langchaingo/vectorstores"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
 	"golang.org/x/exp/slices"
 )
@@ -105,11 +106,24 @@ func WithQueryAttrs(queryAttrs []string) Option {
 	}
 }
 
+// WithDistanceStrategy is an option for setting the distance metric the class in
+// weaviate was created with. Use DistanceStrategyEuclidean for the "l2-squared"
+// metric. If not set the weaviate default, cosine, is assumed. With the dot
+// product and euclidean metrics, the score threshold of a search is sent to
+// weaviate as a distance, since weaviate only supports certainty for cosine.
+func WithDistanceStrategy(strategy vectorstores.DistanceStrategy) Option {
+	return func(p *Store) {
+		p.distanceStrategy = strategy
+	}
+}
+
 func applyClientOptions(opts ...Option) (Store, error) {
 	o := &Store{
 		textKey:      _defaultTextKey,
 		nameSpaceKey: _defaultNameSpaceKey,
 		nameSpace:    _defaultNameSpace,
+
+		distanceStrategy: vectorstores.DistanceStrategyCosine,
 	}
 
 	for _, opt := range opts {
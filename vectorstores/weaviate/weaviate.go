@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
@@ -34,6 +35,8 @@ var (
 	ErrInvalidScoreThreshold = errors.New(
 		"score threshold must be between 0 and 1")
 	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrBatchImport is returned when weaviate rejects objects of a batch import.
+	ErrBatchImport = errors.New("batch import failed")
 )
 
 // Store is a wrapper around the weaviate client.
@@ -58,9 +61,15 @@ type Store struct {
 
 	// optional
 	queryAttrs []string
+
+	distanceStrategy vectorstores.DistanceStrategy
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.Indexer       = Store{}
+	_ vectorstores.ScoreSearcher = Store{}
+	_ vectorstores.MMRSearcher   = Store{}
+)
 
 // New creates a new Store with options.
 // When using weaviate,
@@ -86,6 +95,42 @@ func New(opts ...Option) (Store, error) {
 }
 
 func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) error {
+	_, err := s.AddDocumentsReturnIDs(ctx, docs, options...)
+	return err
+}
+
+// AddDocumentsReturnIDs adds the documents like AddDocuments and returns the
+// generated IDs of the objects.
+func (s Store) AddDocumentsReturnIDs(
+	ctx context.Context,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, uuid.New().String())
+	}
+
+	if err := s.UpsertDocuments(ctx, ids, docs, options...); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the embedder
+// and batch imports the objects with the given IDs. The IDs must be UUIDs.
+// Existing objects with the same IDs are replaced.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) != len(docs) {
+		return vectorstores.ErrIDsDocumentsMismatch
+	}
+
 	opts := s.getOptions(options...)
 	nameSpace := s.getNameSpace(opts)
 
@@ -119,15 +164,131 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document, options
 	for i := range docs {
 		objects = append(objects, &models.Object{
 			Class:      s.indexName,
-			ID:         strfmt.UUID(uuid.New().String()),
+			ID:         strfmt.UUID(ids[i]),
 			Vector:     convertVector(vectors[i]),
 			Properties: metadatas[i],
 		})
 	}
-	if _, err := s.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx); err != nil {
+	results, err := s.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx)
+	if err != nil {
 		return err
 	}
-	return nil
+	return batchErrors(results)
+}
+
+// batchErrors returns the errors weaviate reported for the objects of a batch
+// import as one error, or nil if all objects were imported.
+func batchErrors(results []models.ObjectsGetResponse) error {
+	var messages []string
+	for _, result := range results {
+		if result.Result == nil || result.Result.Errors == nil {
+			continue
+		}
+		for _, e := range result.Result.Errors.Error {
+			messages = append(messages, fmt.Sprintf("object %s: %s", result.ID, e.Message))
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrBatchImport, strings.Join(messages, "; "))
+}
+
+// GetDocuments returns the objects in the name space with the given IDs.
+func (s Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) (map[string]schema.Document, error) {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	docs := make(map[string]schema.Document, len(ids))
+	for _, id := range ids {
+		objects, err := s.client.Data().ObjectsGetter().
+			WithClassName(s.indexName).
+			WithID(id).
+			Do(ctx)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(objects) == 0 {
+			continue
+		}
+
+		properties, ok := objects[0].Properties.(map[string]any)
+		if !ok {
+			return nil, ErrInvalidResponse
+		}
+		if properties[s.nameSpaceKey] != nameSpace {
+			continue
+		}
+		pageContent, ok := properties[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(properties, s.textKey)
+
+		docs[id] = schema.Document{
+			PageContent: pageContent,
+			Metadata:    properties,
+		}
+	}
+
+	return docs, nil
+}
+
+// DeleteDocuments deletes the objects in the name space with the given IDs.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	idFilters := make([]*filters.WhereBuilder, 0, len(ids))
+	for _, id := range ids {
+		idFilters = append(idFilters, filters.Where().WithPath([]string{"id"}).WithOperator(filters.Equal).WithValueString(id))
+	}
+	idFilter := idFilters[0]
+	if len(idFilters) > 1 {
+		idFilter = filters.Where().WithOperator(filters.Or).WithOperands(idFilters)
+	}
+
+	whereBuilder, err := s.createWhereBuilder(nameSpace, idFilter)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Batch().ObjectsBatchDeleter().
+		WithClassName(s.indexName).
+		WithWhere(whereBuilder).
+		Do(ctx)
+
+	return err
+}
+
+// DeleteByFilter deletes the objects in the name space matching the filter. The
+// filter must be a *filters.WhereBuilder.
+func (s Store) DeleteByFilter(ctx context.Context, filter any, options ...vectorstores.Option) error {
+	nameSpace := s.getNameSpace(s.getOptions(options...))
+
+	if filter == nil {
+		return ErrInvalidFilter
+	}
+
+	whereBuilder, err := s.createWhereBuilder(nameSpace, filter)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Batch().ObjectsBatchDeleter().
+		WithClassName(s.indexName).
+		WithWhere(whereBuilder).
+		Do(ctx)
+
+	return err
 }
 
 func (s Store) SimilaritySearch(
@@ -136,41 +297,123 @@ func (s Store) SimilaritySearch(
 	numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	scored, err := s.SimilaritySearchWithScore(ctx, query, numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(scored))
+	for _, d := range scored {
+		docs = append(docs, d.Document)
+	}
+
+	return docs, nil
+}
+
+// SimilaritySearchWithScore works like SimilaritySearch, but also returns the
+// score of each document. With the cosine distance strategy the score is the
+// certainty weaviate reports. With dot product it is the dot product, and with
+// euclidean it is the squared euclidean distance.
+func (s Store) SimilaritySearchWithScore(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]vectorstores.ScoredDocument, error) {
+	res, _, err := s.queryNearVector(ctx, query, numDocuments, false, options...)
+	if err != nil {
+		return nil, err
+	}
+	return s.parseDocumentsByGraphQLResponse(res)
+}
+
+// MaxMarginalRelevanceSearch queries the fetchK objects most similar to the query
+// and returns k documents selected from them with maximal marginal relevance.
+func (s Store) MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	query string,
+	k, fetchK int,
+	lambda float64,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	res, queryVector, err := s.queryNearVector(ctx, query, fetchK, true, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	scored, err := s.parseDocumentsByGraphQLResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors := make([][]float64, 0, len(scored))
+	for _, d := range scored {
+		v, err := popVector(d.Document.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v)
+	}
+
+	selected, err := vectorstores.MaximalMarginalRelevance(queryVector, vectors, k, lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(selected))
+	for _, i := range selected {
+		docs = append(docs, scored[i].Document)
+	}
+
+	return docs, nil
+}
+
+// queryNearVector embeds the query and queries the objects nearest to it. The
+// vector of the query is returned with the response.
+func (s Store) queryNearVector(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	withVector bool,
+	options ...vectorstores.Option,
+) (*models.GraphQLResponse, []float64, error) {
 	opts := s.getOptions(options...)
 	nameSpace := s.getNameSpace(opts)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	filter := s.getFilters(opts)
 	whereBuilder, err := s.createWhereBuilder(nameSpace, filter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res, err := s.client.GraphQL().
 		Get().
-		WithNearVector(s.client.GraphQL().
-			NearVectorArgBuilder().
-			WithVector(convertVector(vector)).
-			WithCertainty(scoreThreshold),
-		).
+		WithNearVector(s.createNearVector(vector, scoreThreshold)).
 		WithWhere(whereBuilder).
 		WithClassName(s.indexName).
 		WithLimit(numDocuments).
-		WithFields(s.createFields()...).Do(ctx)
+		WithFields(s.createFields(withVector)...).Do(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return s.parseDocumentsByGraphQLResponse(res)
+	return res, vector, nil
+}
+
+// DistanceStrategy returns the distance metric of the weaviate class, as set
+// with the WithDistanceStrategy option.
+func (s Store) DistanceStrategy() vectorstores.DistanceStrategy {
+	return s.distanceStrategy
 }
 
-func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]schema.Document, error) {
+func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]vectorstores.ScoredDocument, error) {
 	if len(res.Errors) > 0 {
 		messages := make([]string, 0, len(res.Errors))
 		for _, e := range res.Errors {
@@ -187,7 +430,7 @@ func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]s
 	if !ok || len(items) == 0 {
 		return nil, ErrEmptyResponse
 	}
-	docs := make([]schema.Document, 0, len(items))
+	docs := make([]vectorstores.ScoredDocument, 0, len(items))
 	for _, item := range items {
 		itemMap, ok := item.(map[string]any)
 		if !ok {
@@ -198,15 +441,41 @@ func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]s
 			return nil, ErrMissingTextKey
 		}
 		delete(itemMap, s.textKey)
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    itemMap,
+		doc := vectorstores.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    itemMap,
+			},
+			Score: s.getScore(itemMap),
 		}
 		docs = append(docs, doc)
 	}
 	return docs, nil
 }
 
+// getScore reads the score of an item from the additional fields of the response.
+func (s Store) getScore(item map[string]any) float64 {
+	additional, ok := item["_additional"].(map[string]any)
+	if !ok {
+		return 0
+	}
+
+	switch s.distanceStrategy {
+	case vectorstores.DistanceStrategyCosine:
+		certainty, _ := additional["certainty"].(float64)
+		return certainty
+	case vectorstores.DistanceStrategyDotProduct:
+		// Weaviate reports the negative dot product as the distance.
+		distance, _ := additional["distance"].(float64)
+		return -distance
+	case vectorstores.DistanceStrategyEuclidean:
+		distance, _ := additional["distance"].(float64)
+		return distance
+	}
+
+	return 0
+}
+
 func (s Store) getNameSpace(opts vectorstores.Options) string {
 	if opts.NameSpace != "" {
 		return opts.NameSpace
@@ -252,22 +521,77 @@ func (s Store) createWhereBuilder(namespace string, filter any) (*filters.WhereB
 	}), nil
 }
 
-func (s Store) createFields() []graphql.Field {
+// createNearVector returns the near vector argument of the query. Weaviate
+// only supports certainty for cosine, so the score threshold of other metrics
+// is sent as the largest distance to return: the negative threshold for the
+// dot product, and the threshold itself for the euclidean distance.
+func (s Store) createNearVector(vector []float64, scoreThreshold float32) *graphql.NearVectorArgumentBuilder {
+	nearVector := s.client.GraphQL().NearVectorArgBuilder().WithVector(convertVector(vector))
+	switch s.distanceStrategy {
+	case vectorstores.DistanceStrategyDotProduct:
+		if scoreThreshold != 0 {
+			nearVector = nearVector.WithDistance(-scoreThreshold)
+		}
+	case vectorstores.DistanceStrategyEuclidean:
+		if scoreThreshold != 0 {
+			nearVector = nearVector.WithDistance(scoreThreshold)
+		}
+	default:
+		nearVector = nearVector.WithCertainty(scoreThreshold)
+	}
+	return nearVector
+}
+
+func (s Store) createFields(withVector bool) []graphql.Field {
 	fields := make([]graphql.Field, 0, len(s.queryAttrs))
 	for _, attr := range s.queryAttrs {
 		fields = append(fields, graphql.Field{
 			Name: attr,
 		})
 	}
+	additional := []graphql.Field{{Name: "distance"}}
+	if s.distanceStrategy == vectorstores.DistanceStrategyCosine {
+		additional = append(additional, graphql.Field{Name: "certainty"})
+	}
+	if withVector {
+		additional = append(additional, graphql.Field{Name: "vector"})
+	}
 	fields = append(fields, graphql.Field{
-		Name: "_additional",
-		Fields: []graphql.Field{
-			{Name: "certainty"},
-		},
+		Name:   "_additional",
+		Fields: additional,
 	})
 	return fields
 }
 
+// popVector removes the vector from the additional fields in the metadata and
+// returns it.
+func popVector(metadata map[string]any) ([]float64, error) {
+	additional, ok := metadata["_additional"].(map[string]any)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	values, ok := additional["vector"].([]any)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	delete(additional, "vector")
+
+	vector := make([]float64, 0, len(values))
+	for _, value := range values {
+		f, ok := value.(float64)
+		if !ok {
+			return nil, ErrInvalidResponse
+		}
+		vector = append(vector, f)
+	}
+	return vector, nil
+}
+
+func isNotFound(err error) bool {
+	var clientErr *fault.WeaviateClientError
+	return errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound
+}
+
 func convertVector(v []float64) []float32 {
 	v32 := make([]float32, len(v))
 	for i, f := range v {
@@ -2,13 +2,18 @@ package weaviate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/aresa7796/langchaingo/chains"
 	openaiEmbeddings "github.com/aresa7796/langchaingo/embeddings/openai"
+	"github.com/aresa7796/langchaingo/llms/fake"
 	"github.com/aresa7796/langchaingo/llms/openai"
 	"github.com/aresa7796/langchaingo/schema"
 	"github.com/aresa7796/langchaingo/vectorstores"
@@ -537,3 +542,272 @@ func TestWeaviateAsRetrieverWithMetadataFilters(t *testing.T) {
 	require.NotContains(t, result, "orange", "expected not orange in result")
 	require.NotContains(t, result, "yellow", "expected not yellow in result")
 }
+
+func TestWeaviateStoreRestIndexer(t *testing.T) {
+	t.Parallel()
+
+	scheme, host := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	store, err := New(
+		WithScheme(scheme),
+		WithHost(host),
+		WithEmbedder(e),
+		WithNameSpace(uuid.New().String()),
+		WithIndexName(randomizedCamelCaseClass()),
+		WithQueryAttrs([]string{"country"}),
+	)
+	require.NoError(t, err)
+
+	err = createTestClass(context.Background(), store)
+	require.NoError(t, err)
+
+	ids, err := store.AddDocumentsReturnIDs(context.Background(), []schema.Document{
+		{PageContent: "tokyo", Metadata: map[string]any{"country": "japan"}},
+		{PageContent: "potato", Metadata: map[string]any{"country": "ireland"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	err = store.UpsertDocuments(context.Background(), ids[:1], []schema.Document{
+		{PageContent: "osaka", Metadata: map[string]any{"country": "japan"}},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.GetDocuments(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "osaka", docs[ids[0]].PageContent)
+
+	err = store.DeleteDocuments(context.Background(), ids[:1])
+	require.NoError(t, err)
+
+	err = store.DeleteByFilter(context.Background(), filters.Where().
+		WithPath([]string{"country"}).
+		WithOperator(filters.Equal).
+		WithValueString("ireland"))
+	require.NoError(t, err)
+
+	docs, err = store.GetDocuments(context.Background(), ids)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestWeaviateStoreRestSimilaritySearchWithScore(t *testing.T) {
+	t.Parallel()
+
+	scheme, host := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	store, err := New(
+		WithScheme(scheme),
+		WithHost(host),
+		WithEmbedder(e),
+		WithNameSpace(uuid.New().String()),
+		WithIndexName(randomizedCamelCaseClass()),
+	)
+	require.NoError(t, err)
+	require.Equal(t, vectorstores.DistanceStrategyCosine, store.DistanceStrategy())
+
+	err = createTestClass(context.Background(), store)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.SimilaritySearchWithScore(context.Background(), "japan", 2)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "tokyo", docs[0].Document.PageContent)
+	require.Greater(t, docs[0].Score, docs[1].Score)
+}
+
+func TestWeaviateStoreRestMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+
+	scheme, host := getValues(t)
+	e, err := openaiEmbeddings.NewOpenAI()
+	require.NoError(t, err)
+
+	store, err := New(
+		WithScheme(scheme),
+		WithHost(host),
+		WithEmbedder(e),
+		WithNameSpace(uuid.New().String()),
+		WithIndexName(randomizedCamelCaseClass()),
+	)
+	require.NoError(t, err)
+
+	err = createTestClass(context.Background(), store)
+	require.NoError(t, err)
+
+	err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Tokyo, Japan"},
+		{PageContent: "Osaka"},
+		{PageContent: "Potato"},
+	})
+	require.NoError(t, err)
+
+	docs, err := vectorstores.ToRetriever(store, 2, vectorstores.WithMaxMarginalRelevance(4, 0.5)).
+		GetRelevantDocuments(context.Background(), "Tokyo")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "Tokyo", docs[0].PageContent)
+	require.NotEqual(t, "Tokyo, Japan", docs[1].PageContent)
+}
+
+func TestNearVectorQueryByDistanceStrategy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		strategy      vectorstores.DistanceStrategy
+		argument      string
+		withCertainty bool
+		score         float64
+	}{
+		{strategy: vectorstores.DistanceStrategyCosine, argument: "certainty: 0.5", withCertainty: true, score: 0.9},
+		{strategy: vectorstores.DistanceStrategyDotProduct, argument: "distance: -0.5", score: 0.2},
+		{strategy: vectorstores.DistanceStrategyEuclidean, argument: "distance: 0.5", score: -0.2},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			t.Parallel()
+
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/graphql" {
+					fmt.Fprint(w, `{}`)
+					return
+				}
+				var body struct {
+					Query string `json:"query"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				query = body.Query
+				fmt.Fprint(w, `{"data": {"Get": {"Docs": [{"text": "foo", "_additional": {"certainty": 0.9, "distance": -0.2}}]}}}`)
+			}))
+			defer server.Close()
+
+			store, err := New(
+				WithScheme("http"),
+				WithHost(strings.TrimPrefix(server.URL, "http://")),
+				WithIndexName("Docs"),
+				WithEmbedder(fake.NewEmbedder(8)),
+				WithDistanceStrategy(tc.strategy),
+			)
+			require.NoError(t, err)
+
+			docs, err := store.SimilaritySearchWithScore(context.Background(), "foo", 1,
+				vectorstores.WithScoreThreshold(0.5))
+			require.NoError(t, err)
+			require.Len(t, docs, 1)
+			require.Equal(t, tc.score, docs[0].Score)
+
+			require.Contains(t, query, tc.argument)
+			require.Equal(t, tc.withCertainty, strings.Contains(query, "certainty"))
+			require.Contains(t, query, "distance")
+		})
+	}
+}
+
+func TestDeleteDocumentsInNameSpace(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/batch/objects" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		require.Equal(t, http.MethodDelete, r.Method)
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = string(b)
+		fmt.Fprint(w, `{"results": {"matches": 1, "successful": 1}}`)
+	}))
+	defer server.Close()
+
+	store, err := New(
+		WithScheme("http"),
+		WithHost(strings.TrimPrefix(server.URL, "http://")),
+		WithIndexName("Docs"),
+		WithEmbedder(fake.NewEmbedder(8)),
+	)
+	require.NoError(t, err)
+
+	err = store.DeleteDocuments(context.Background(), []string{"id-1", "id-2"}, vectorstores.WithNameSpace("tenant-a"))
+	require.NoError(t, err)
+
+	var request struct {
+		Match struct {
+			Class string `json:"class"`
+			Where struct {
+				Operator string `json:"operator"`
+				Operands []struct {
+					Operator    string   `json:"operator"`
+					Path        []string `json:"path"`
+					ValueString string   `json:"valueString"`
+					Operands    []struct {
+						Path        []string `json:"path"`
+						ValueString string   `json:"valueString"`
+					} `json:"operands"`
+				} `json:"operands"`
+			} `json:"where"`
+		} `json:"match"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	require.Equal(t, "Docs", request.Match.Class)
+	require.Equal(t, "And", request.Match.Where.Operator)
+	require.Len(t, request.Match.Where.Operands, 2)
+
+	nameSpace := request.Match.Where.Operands[0]
+	require.Equal(t, []string{"nameSpace"}, nameSpace.Path)
+	require.Equal(t, "tenant-a", nameSpace.ValueString)
+
+	ids := request.Match.Where.Operands[1]
+	require.Equal(t, "Or", ids.Operator)
+	require.Len(t, ids.Operands, 2)
+	require.Equal(t, []string{"id"}, ids.Operands[0].Path)
+	require.Equal(t, "id-1", ids.Operands[0].ValueString)
+	require.Equal(t, "id-2", ids.Operands[1].ValueString)
+}
+
+func TestUpsertDocumentsObjectErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/batch/objects" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		require.Equal(t, http.MethodPost, r.Method)
+		fmt.Fprint(w, `[
+			{"id": "6a4d3c1e-0000-4000-8000-000000000001", "result": {}},
+			{"id": "6a4d3c1e-0000-4000-8000-000000000002",
+				"result": {"errors": {"error": [{"message": "invalid property"}]}}}
+		]`)
+	}))
+	defer server.Close()
+
+	store, err := New(
+		WithScheme("http"),
+		WithHost(strings.TrimPrefix(server.URL, "http://")),
+		WithIndexName("Docs"),
+		WithEmbedder(fake.NewEmbedder(8)),
+	)
+	require.NoError(t, err)
+
+	err = store.UpsertDocuments(context.Background(),
+		[]string{"6a4d3c1e-0000-4000-8000-000000000001", "6a4d3c1e-0000-4000-8000-000000000002"},
+		[]schema.Document{{PageContent: "a"}, {PageContent: "b"}})
+	require.ErrorIs(t, err, ErrBatchImport)
+	require.ErrorContains(t, err, "object 6a4d3c1e-0000-4000-8000-000000000002: invalid property")
+}